@@ -47,6 +47,8 @@ func doMain() (err error) {
 			)),
 		app.Server(server.GrpcServerScanner("control-grpc-server",
 			server.ControlServer(),
+			server.CertServer(),
+			server.DNSServer(),
 			server.HttpServerFactory("control-gateway-server"),
 			server.TlsConfigFactory("tls-config"),
 			server.TemplatePage("/", "resources:templates/index.tmpl"),
@@ -55,6 +57,7 @@ func doMain() (err error) {
 		app.Client(client.ClientScanner(
 			client.GrpcClientFactory("control-grpc-client"),
 			client.ControlClient(),
+			client.DNSClient(),
 			client.AnyTlsConfigFactory("tls-config"),
 			)),
 		).
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"context"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/sprintpb"
+	"reflect"
+	"time"
+)
+
+/**
+Framework level extensions of the sprint interfaces.
+*/
+
+// PreviousSignerSuffix names the copy of the self signer kept after the rotation until it is retired.
+const PreviousSignerSuffix = ".previous"
+
+var CertificateMonitorClass = reflect.TypeOf((*CertificateMonitor)(nil)).Elem()
+
+type CertificateEvent struct {
+	Zone       string
+	Domains    []string
+	NotAfter   time.Time
+	DaysLeft   int
+	Threshold  int     // days threshold that raised the event, zero for other alerts
+	Reason     string
+	Error      string  // last renewal error if any
+	Serial     string  // serial number of the unexpected certificate found in CT log
+	Issuer     string  // issuer of the unexpected certificate found in CT log
+}
+
+type CertificateMonitor interface {
+	sprint.Component
+	glue.InitializingBean
+	glue.DisposableBean
+
+	/**
+	Checks all zones and raises events for newly crossed thresholds
+	*/
+	Check() error
+
+	/**
+	Lists certificates expiring within the given duration
+	*/
+	ListExpiring(within time.Duration) ([]*CertificateEvent, error)
+
+	/**
+	Sends event to all configured notification channels
+	*/
+	Notify(event *CertificateEvent) error
+
+	ExecuteCommand(cmd string, args []string) (string, error)
+
+}
+
+var CertificateAuthorityClass = reflect.TypeOf((*CertificateAuthority)(nil)).Elem()
+
+type CertificateAuthority interface {
+
+	/**
+	Validates certificate request and signs it by the self signer, username is used for the audit record
+	*/
+	SignCSR(req *pb.SignCSRRequest, username string) (*pb.SignCSRResponse, error)
+
+	ListIssued(signer string, cb func(*pb.IssuedCertificate) bool) error
+
+}
+
+var CTLogClientClass = reflect.TypeOf((*CTLogClient)(nil)).Elem()
+
+type CTLogEntry struct {
+	ID          string
+	Issuer      string
+	CommonName  string
+	DNSNames    []string
+	Serial      string  // lower case hex without leading zeros
+	NotBefore   time.Time
+	NotAfter    time.Time
+}
+
+type CTLogClient interface {
+
+	/**
+	Searches the Certificate Transparency log for certificates issued for the domain
+	*/
+	Search(ctx context.Context, domain string) ([]*CTLogEntry, error)
+
+}
+
+var CertificateTransparencyClass = reflect.TypeOf((*CertificateTransparency)(nil)).Elem()
+
+type CertificateTransparency interface {
+	sprint.Component
+	glue.InitializingBean
+	glue.DisposableBean
+
+	/**
+	Checks the CT log for all ACME zones and notifies about unexpected certificates
+	*/
+	Check() error
+
+}
+
+var CertificateZoneServiceClass = reflect.TypeOf((*CertificateZoneService)(nil)).Elem()
+
+type CertificateZoneService interface {
+
+	/**
+	Creates the zone and issues or uploads its certificate, returns the saved zone and the human readable report
+	*/
+	CreateZone(ctx context.Context, req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error)
+
+	/**
+	Renews the certificate of the zone, ACME calls join the trace of the context
+	*/
+	RenewZone(ctx context.Context, zone string) error
+
+	/**
+	Exports the certificate bundle of the zone in the format, returns the content and the file extension
+	*/
+	ExportZone(zone, format, password string) ([]byte, string, error)
+
+}
+
+var CertClientClass = reflect.TypeOf((*CertClient)(nil)).Elem()
+
+type CertClient interface {
+
+	/**
+	Requests the certificate bundle of the zone from the running server, the caller writes it
+	*/
+	ExportZone(zone, format, password string) ([]byte, string, error)
+
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"reflect"
+)
+
+var DNSProviderClientFactoryClass = reflect.TypeOf((*DNSProviderClientFactory)(nil)).Elem()
+
+type DNSProviderClientFactory interface {
+
+	/**
+	Name of the lego DNS provider served by the record client, like 'cloudflare' or 'route53'
+	*/
+	ProviderName() string
+
+	/**
+	Creates record management client for the generic lego DNS provider
+	*/
+	NewClient() (sprint.DNSProviderClient, error)
+
+}
+
+var DNSProviderFactoryClass = reflect.TypeOf((*DNSProviderFactory)(nil)).Elem()
+
+type DNSProviderFactory interface {
+
+	/**
+	DNS providers created from the configuration, they are used the same way as the DNS provider beans
+	*/
+	Providers() []sprint.DNSProvider
+
+}
+
+var DNSServiceClass = reflect.TypeOf((*DNSService)(nil)).Elem()
+
+type DNSService interface {
+	glue.NamedBean
+	glue.InitializingBean
+
+	/**
+	Executes dns command: list, add, remove, providers
+	*/
+	ExecuteCommand(cmd string, args []string) (string, error)
+
+}
+
+var DNSClientClass = reflect.TypeOf((*DNSClient)(nil)).Elem()
+
+type DNSClient interface {
+
+	DNSCommand(command string, args []string) (string, error)
+
+	DynDNSCommand(command string, args []string) (string, error)
+
+}
+
+var DynDNSServiceClass = reflect.TypeOf((*DynDNSService)(nil)).Elem()
+
+type DynDNSService interface {
+	sprint.DynDNSService
+	sprint.Component
+	glue.DisposableBean
+
+	/**
+	Updates the records of all zones if the external address was changed since the last sync or if forced
+	*/
+	Sync(force bool) error
+
+	/**
+	Executes dyndns command: status, sync
+	*/
+	ExecuteCommand(cmd string, args []string) (string, error)
+
+}
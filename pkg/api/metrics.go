@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/prometheus/client_golang/prometheus"
+	"reflect"
+	"time"
+)
+
+var MetricsRegistryClass = reflect.TypeOf((*MetricsRegistry)(nil)).Elem()
+
+/**
+Prometheus registry of the application, beans register their own collectors in it or implement prometheus.Collector to be registered automatically
+*/
+type MetricsRegistry interface {
+	glue.InitializingBean
+	prometheus.Registerer
+	prometheus.Gatherer
+
+	/**
+	Registers the collector, returns the already registered one if the same collector exists
+	*/
+	RegisterOrGet(c prometheus.Collector) (prometheus.Collector, error)
+
+	/**
+	Counts the job run by result and observes its duration since the start
+	*/
+	ObserveJobRun(name string, start time.Time, err error)
+
+	/**
+	Counts the storage management command by result and observes its duration since the start
+	*/
+	ObserveStorageCommand(storage, command string, start time.Time, err error)
+
+}
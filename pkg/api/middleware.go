@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"reflect"
+)
+
+var HttpMiddlewareClass = reflect.TypeOf((*HttpMiddleware)(nil)).Elem()
+
+/**
+Middleware applied by the http server factory around the mux, built-in middlewares are enabled by the server options
+*/
+type HttpMiddleware interface {
+
+	/**
+	Middlewares are applied in ascending order of priority, the lowest priority handles the request first
+	*/
+	Priority() int
+
+	/**
+	Wraps the handler of the server with the bean name, returns the same handler to skip the server
+	*/
+	Wrap(server string, next http.Handler) http.Handler
+
+}
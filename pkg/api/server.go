@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"context"
+	"github.com/codeallergy/sprint"
+	"net"
+	"os"
+	"reflect"
+)
+
+/**
+Listener file descriptor passed to the new process on graceful restart, the name is used to match the listener by server
+*/
+type ListenerFile struct {
+	Name  string
+	File  *os.File
+}
+
+var GracefulServerClass = reflect.TypeOf((*GracefulServer)(nil)).Elem()
+
+type GracefulServer interface {
+	sprint.Server
+
+	/**
+	Duplicates bound listeners, the caller owns the files
+	*/
+	ListenerFiles() ([]ListenerFile, error)
+
+	/**
+	Returns addresses of all bound listeners, ListenAddress returns only the first one
+	*/
+	ListenAddresses() []net.Addr
+
+	/**
+	Stops accepting new connections and waits for in-flight requests, forcibly closes connections when context is done
+	*/
+	Shutdown(ctx context.Context) error
+
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package api
+
+import (
+	"context"
+	"github.com/codeallergy/glue"
+	"reflect"
+)
+
+var TracingServiceClass = reflect.TypeOf((*TracingService)(nil)).Elem()
+
+/**
+Installs the OpenTelemetry tracer provider configured by 'tracing.*' properties, the tracing is disabled if no exporter configured
+*/
+type TracingService interface {
+	glue.InitializingBean
+	glue.DisposableBean
+
+	/**
+	Returns true if spans are exported
+	*/
+	Enabled() bool
+
+	/**
+	Exports all finished spans
+	*/
+	ForceFlush(ctx context.Context) error
+
+}
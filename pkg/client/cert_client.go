@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package client
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"google.golang.org/grpc"
+)
+
+type implCertClient struct {
+	GrpcConn   *grpc.ClientConn                `inject`
+	client     pb.CertServiceClient
+}
+
+/**
+	The connection is owned and closed by the control client
+ */
+func CertClient() api.CertClient {
+	return &implCertClient{}
+}
+
+func (t *implCertClient) PostConstruct() error {
+	t.client = pb.NewCertServiceClient(t.GrpcConn)
+	return nil
+}
+
+func (t *implCertClient) ExportZone(zone, format, password string) ([]byte, string, error) {
+
+	req := &pb.ExportZoneRequest {
+		Zone: zone,
+		Format: format,
+		Password: password,
+	}
+
+	if resp, err := t.client.ExportZone(context.Background(), req); err != nil {
+		return nil, "", err
+	} else {
+		return resp.Content, resp.Ext, nil
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package client
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"google.golang.org/grpc"
+)
+
+type implDNSClient struct {
+	GrpcConn   *grpc.ClientConn                `inject`
+	client     pb.DNSServiceClient
+}
+
+/**
+	The connection is owned and closed by the control client
+ */
+func DNSClient() api.DNSClient {
+	return &implDNSClient{}
+}
+
+func (t *implDNSClient) PostConstruct() error {
+	t.client = pb.NewDNSServiceClient(t.GrpcConn)
+	return nil
+}
+
+func (t *implDNSClient) DNSCommand(command string, args []string) (string, error) {
+
+	req := &pb.DNSCommand {
+		Command: command,
+		Args: args,
+	}
+
+	if resp, err := t.client.DNS(context.Background(), req); err != nil {
+		return "", err
+	} else {
+		return resp.Content, nil
+	}
+}
+
+func (t *implDNSClient) DynDNSCommand(command string, args []string) (string, error) {
+
+	req := &pb.DNSCommand {
+		Command: command,
+		Args: args,
+	}
+
+	if resp, err := t.client.DynDNS(context.Background(), req); err != nil {
+		return "", err
+	} else {
+		return resp.Content, nil
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/codeallergy/sprint"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 )
@@ -58,6 +60,12 @@ func (t *implGrpcClientFactory) Object() (object interface{}, err error) {
 
 	// try to get normal property
 	connectAddr := t.Properties.GetString(fmt.Sprintf("%s.connect-address", t.beanName), "")
+	if connectAddr == "" {
+		// prefer the local unix socket, authenticated by peer credentials
+		if socketPath := t.getSocketPath(); socketPath != "" {
+			return t.doDialSocket(socketPath)
+		}
+	}
 	if connectAddr == "" {
 		// try to convert from server address
 		grpcListenAddr := t.Properties.GetString( "control-grpc-server.listen-address", "")
@@ -83,6 +91,13 @@ func (t *implGrpcClientFactory) Singleton() bool {
 }
 
 func (t *implGrpcClientFactory) getConnectAddress(listenAddr string) string {
+	// connect to the first address if server listens many
+	if i := strings.IndexByte(listenAddr, ';'); i != -1 {
+		listenAddr = strings.TrimSpace(listenAddr[:i])
+	}
+	if strings.HasPrefix(listenAddr, "[::]:") {
+		return "[::1]" + listenAddr[4:]
+	}
 	if strings.HasPrefix(listenAddr, "0.0.0.0:") {
 		return "127.0.0.1" + listenAddr[7:]
 	}
@@ -92,6 +107,44 @@ func (t *implGrpcClientFactory) getConnectAddress(listenAddr string) string {
 	return listenAddr
 }
 
+/**
+	Returns the unix socket path of the control gRPC server if the socket exist.
+*/
+
+func (t *implGrpcClientFactory) getSocketPath() string {
+
+	socketFile := t.Properties.GetString("control-grpc-server.unix-socket", "")
+	if socketFile == "" {
+		return ""
+	}
+
+	if !filepath.IsAbs(socketFile) {
+		runDir := t.Properties.GetString("application.run.dir", "")
+		if runDir == "" {
+			runDir = filepath.Join(t.Application.ApplicationDir(), "run")
+		}
+		socketFile = filepath.Join(runDir, socketFile)
+	}
+
+	if fi, err := os.Stat(socketFile); err != nil || fi.Mode() & os.ModeSocket == 0 {
+		return ""
+	}
+
+	return socketFile
+}
+
+func (t *implGrpcClientFactory) doDialSocket(socketPath string) (*grpc.ClientConn, error) {
+
+	opts := []grpc.DialOption{ grpc.WithInsecure() }
+
+	maxMessageSize := t.Properties.GetInt(fmt.Sprintf("%s.max.message.size", t.beanName), 0)
+	if maxMessageSize != 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)))
+	}
+
+	return grpc.Dial("unix://" + socketPath, opts...)
+}
+
 func (t *implGrpcClientFactory) doDial(connectAddr string) (*grpc.ClientConn, error) {
 
 	var opts []grpc.DialOption
@@ -50,6 +50,8 @@ func (t *controlClientScanner) ClientBeans() []interface{} {
 	beans := []interface{}{
 		GrpcClientFactory("control-grpc-client"),
 		ControlClient(),
+		DNSClient(),
+		CertClient(),
 		&struct {
 			ControlClient []sprint.ControlClient `inject`
 		}{},
@@ -15,20 +15,30 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/util"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"os"
+	"strings"
 )
 
+var ErrCertClientNotFound = errors.New("cert client not found in client context")
+
 type implCertCommand struct {
 	Context     glue.Context    `inject`
 	Application sprint.Application `inject`
 }
 
 type coreDomainContext struct {
-	CertificateService sprint.CertificateService `inject`
+	CertificateService     sprint.CertificateService     `inject`
+	CertificateMonitor     api.CertificateMonitor        `inject`
+	CertificateZoneService api.CertificateZoneService    `inject`
 }
 
 func CertCommand() sprint.Command {
@@ -40,7 +50,7 @@ func (t *implCertCommand) BeanName() string {
 }
 
 func (t *implCertCommand) Desc() string {
-	return "cert commands: [list, dump, upload, create, renew, remove, client, acme, self, manager]"
+	return "cert commands: [list, dump, export, upload, create, renew, remove, client, acme, self, manager, expiring]"
 }
 
 func (t *implCertCommand) Run(args []string) error {
@@ -50,6 +60,10 @@ func (t *implCertCommand) Run(args []string) error {
 	cmd := args[0]
 	args = args[1:]
 
+	if cmd == "export" {
+		return t.export(args)
+	}
+
 	err := doWithControlClient(t.Context, func(client sprint.ControlClient) error {
 		content, err := client.CertificateCommand(cmd, args)
 		if err == nil {
@@ -70,6 +84,14 @@ func (t *implCertCommand) Run(args []string) error {
 
 	c := new(coreDomainContext)
 	return doInCore(t.Context, c, func(core glue.Context) error {
+		if cmd == "expiring" {
+			content, err := c.CertificateMonitor.ExecuteCommand(cmd, args)
+			if err != nil {
+				return err
+			}
+			println(content)
+			return nil
+		}
 		content, err :=  c.CertificateService.ExecuteCommand(cmd, args)
 		if err != nil {
 			return err
@@ -79,3 +101,78 @@ func (t *implCertCommand) Run(args []string) error {
 	})
 
 }
+
+/**
+	The bundle contains the private key, so it is written by the client and never by the server
+ */
+func (t *implCertCommand) export(args []string) error {
+
+	passwordEnv := strings.ToUpper(fmt.Sprintf("%s_EXPORT_PASSWORD", t.Application.Name()))
+	usage := fmt.Sprintf("Usage: ./%s cert export zone [--format %s] [--password password] [--output file], the password is taken from %s or prompted if omitted", t.Application.Name(), strings.Join(util.ExportFormats, "|"), passwordEnv)
+	if len(args) < 1 {
+		println(usage)
+		return nil
+	}
+
+	zone := args[0]
+	format := "pem"
+	var password, output string
+	passwordSet := false
+
+	for i := 1; i < len(args); i++ {
+		flag := args[i]
+		switch flag {
+		case "--format", "--password", "--output":
+		default:
+			return errors.Errorf("unknown flag '%s', %s", flag, usage)
+		}
+		if i+1 == len(args) {
+			return errors.Errorf("flag '%s' needs a value, %s", flag, usage)
+		}
+		i++
+		switch flag {
+		case "--format":
+			format = strings.ToLower(args[i])
+		case "--password":
+			password, passwordSet = args[i], true
+		case "--output":
+			output = args[i]
+		}
+	}
+
+	if !passwordSet && (format == "pkcs12" || format == "jks") {
+		password = os.Getenv(passwordEnv)
+		if password == "" {
+			password = util.PromptPassword("Enter export password: ")
+		}
+	}
+
+	var content []byte
+	var ext string
+
+	err := doWithCertClient(t.Context, func(client api.CertClient) (err error) {
+		content, ext, err = client.ExportZone(zone, format, password)
+		return err
+	})
+	if err == ErrCertClientNotFound || status.Code(err) == codes.Unavailable {
+		c := new(coreDomainContext)
+		err = doInCore(t.Context, c, func(core glue.Context) (err error) {
+			content, ext, err = c.CertificateZoneService.ExportZone(zone, format, password)
+			return err
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s.%s", zone, ext)
+	}
+
+	if err := ioutil.WriteFile(output, content, 0600); err != nil {
+		return errors.Wrapf(err, "writing export to file '%s'", output)
+	}
+
+	println(fmt.Sprintf("Exported %s to %s", zone, output))
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package cmd
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrDNSClientNotFound = errors.New("dns client not found in client context")
+
+type implDNSCommand struct {
+	Context     glue.Context    `inject`
+	Application sprint.Application `inject`
+}
+
+type coreDNSContext struct {
+	DNSService api.DNSService `inject`
+}
+
+func DNSCommand() sprint.Command {
+	return &implDNSCommand{}
+}
+
+func (t *implDNSCommand) BeanName() string {
+	return "dns"
+}
+
+func (t *implDNSCommand) Desc() string {
+	return "dns commands: [list, add, remove, providers]"
+}
+
+func (t *implDNSCommand) Run(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("dns command needs argument, %s", t.Desc())
+	}
+	cmd := args[0]
+	args = args[1:]
+
+	err := doWithDNSClient(t.Context, func(client api.DNSClient) error {
+		content, err := client.DNSCommand(cmd, args)
+		if err == nil {
+			println(content)
+		}
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+	if err != ErrDNSClientNotFound && status.Code(err) != codes.Unavailable {
+		return err
+	}
+
+	c := new(coreDNSContext)
+	return doInCore(t.Context, c, func(core glue.Context) error {
+		content, err := c.DNSService.ExecuteCommand(cmd, args)
+		if err != nil {
+			return err
+		}
+		println(content)
+		return nil
+	})
+
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package cmd
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type implDynDNSCommand struct {
+	Context     glue.Context    `inject`
+	Application sprint.Application `inject`
+}
+
+type coreDynDNSContext struct {
+	DynDNSService api.DynDNSService `inject`
+}
+
+func DynDNSCommand() sprint.Command {
+	return &implDynDNSCommand{}
+}
+
+func (t *implDynDNSCommand) BeanName() string {
+	return "dyndns"
+}
+
+func (t *implDynDNSCommand) Desc() string {
+	return "dyndns commands: [status, sync]"
+}
+
+func (t *implDynDNSCommand) Run(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("dyndns command needs argument, %s", t.Desc())
+	}
+
+	err := doWithDNSClient(t.Context, func(client api.DNSClient) error {
+		content, err := client.DynDNSCommand(args[0], args[1:])
+		if err == nil {
+			println(content)
+		}
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+	if err != ErrDNSClientNotFound && status.Code(err) != codes.Unavailable {
+		return err
+	}
+
+	c := new(coreDynDNSContext)
+	return doInCore(t.Context, c, func(core glue.Context) error {
+		content, err := c.DynDNSService.ExecuteCommand(args[0], args[1:])
+		if err != nil {
+			return err
+		}
+		println(content)
+		return nil
+	})
+
+}
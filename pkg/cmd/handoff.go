@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/server"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrChildExited = errors.New("child process exited")
+
+/**
+	Graceful restart, bound listeners are passed to the child process by LISTEN_FDS and the child notifies the readiness back to the ready socket.
+*/
+
+type listenerHandoff struct {
+	files       []api.ListenerFile
+	socketPath  string
+	conn        *net.UnixConn
+	exited      chan error
+}
+
+func newListenerHandoff(runDir string, appName string, servers []sprint.Server) (*listenerHandoff, error) {
+
+	t := &listenerHandoff{
+		socketPath: filepath.Join(runDir, fmt.Sprintf("%s-ready-%d.sock", appName, os.Getpid())),
+	}
+
+	for _, srv := range servers {
+		if gs, ok := srv.(api.GracefulServer); ok {
+			files, err := gs.ListenerFiles()
+			if err != nil {
+				t.Close()
+				return nil, err
+			}
+			t.files = append(t.files, files...)
+		}
+	}
+
+	if len(t.files) == 0 {
+		return nil, errors.New("no listeners to pass")
+	}
+
+	if err := util.RemoveFileIfExist(t.socketPath); err != nil {
+		t.Close()
+		return nil, err
+	}
+
+	var err error
+	t.conn, err = net.ListenUnixgram("unixgram", &net.UnixAddr{Name: t.socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Close()
+		return nil, errors.Errorf("can not listen ready socket '%s', %v", t.socketPath, err)
+	}
+
+	return t, nil
+}
+
+func (t *listenerHandoff) prepare(cmd *exec.Cmd) {
+
+	var names []string
+	for _, f := range t.files {
+		cmd.ExtraFiles = append(cmd.ExtraFiles, f.File)
+		names = append(names, f.Name)
+	}
+
+	cmd.Env = append(cmd.Env,
+		fmt.Sprintf("LISTEN_FDS=%d", len(t.files)),
+		fmt.Sprintf("LISTEN_FDNAMES=%s", strings.Join(names, ":")),
+		fmt.Sprintf("%s=%d", server.ListenParentPidEnv, os.Getpid()),
+		fmt.Sprintf("%s=%s", util.RestartReadySocketEnv, t.socketPath))
+}
+
+/**
+	Waits the next notification from the child process, RELEASE=1 when the child is going to open the storages or READY=1 when it is serving,
+	returns ErrChildExited if the child process exited before.
+*/
+
+func (t *listenerHandoff) waitState(child *os.Process, timeout time.Duration) (string, error) {
+
+	if t.exited == nil {
+		t.exited = make(chan error, 1)
+		go func() {
+			state, err := child.Wait()
+			if err == nil {
+				err = errors.Errorf("%v", state)
+			}
+			t.exited <- errors.Wrapf(ErrChildExited, "pid %d, %v", child.Pid, err)
+		}()
+	}
+
+	type notification struct {
+		state  string
+		err    error
+	}
+
+	ready := make(chan notification, 1)
+	go func() {
+		t.conn.SetReadDeadline(time.Now().Add(timeout))
+		buf := make([]byte, 4096)
+		for {
+			n, err := t.conn.Read(buf)
+			if err != nil {
+				ready <- notification{err: err}
+				return
+			}
+			for _, line := range strings.Split(string(buf[:n]), "\n") {
+				if line == util.RestartReleaseState || line == util.RestartReadyState {
+					ready <- notification{state: line}
+					return
+				}
+			}
+		}
+	}()
+
+	select {
+	case err := <-t.exited:
+		// the notification sent before the exit is already queued in the socket
+		select {
+		case n := <-ready:
+			if n.err == nil {
+				t.exited <- err
+				return n.state, nil
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+		return "", err
+	case n := <-ready:
+		return n.state, n.err
+	}
+}
+
+func (t *listenerHandoff) Close() {
+	for _, f := range t.files {
+		f.File.Close()
+	}
+	if t.conn != nil {
+		t.conn.Close()
+		os.Remove(t.socketPath)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"github.com/codeallergy/boltstore"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	handoffStorageEnv = "HANDOFF_TEST_STORAGE"
+	handoffMarkerEnv  = "HANDOFF_TEST_MARKER"
+)
+
+/**
+	Child process of the handoff test, asks the parent to release the storage, opens it and notifies the readiness,
+	without the storage it writes the marker file and notifies the readiness
+*/
+
+func TestHandoffChildProcess(t *testing.T) {
+
+	dataFile := os.Getenv(handoffStorageEnv)
+	markerFile := os.Getenv(handoffMarkerEnv)
+	if dataFile == "" && markerFile == "" {
+		t.Skip("runs only as the child process of the handoff test")
+	}
+
+	if dataFile != "" {
+		if _, err := util.NotifyRestartRelease(); err != nil {
+			os.Exit(4)
+		}
+		storage, err := boltstore.New("config-storage", dataFile, 0600, boltstore.WithTimeout(2 * time.Second))
+		if err != nil {
+			os.Exit(2)
+		}
+		defer storage.Destroy()
+	} else if err := ioutil.WriteFile(markerFile, []byte("ready"), 0600); err != nil {
+		os.Exit(5)
+	}
+
+	if _, err := util.NotifyRestartReady(); err != nil {
+		os.Exit(3)
+	}
+	os.Exit(0)
+}
+
+type stubGracefulServer struct {
+	api.GracefulServer
+	shutdown  func()
+}
+
+func (t *stubGracefulServer) Shutdown(ctx context.Context) error {
+	t.shutdown()
+	return nil
+}
+
+func newTestHandoff(t *testing.T, dir string) *listenerHandoff {
+	h := &listenerHandoff{
+		socketPath: filepath.Join(dir, "ready.sock"),
+	}
+	var err error
+	h.conn, err = net.ListenUnixgram("unixgram", &net.UnixAddr{Name: h.socketPath, Net: "unixgram"})
+	require.NoError(t, err)
+	return h
+}
+
+func startHandoffChild(h *listenerHandoff, env string) func() (*os.Process, error) {
+	return func() (*os.Process, error) {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestHandoffChildProcess$")
+		cmd.Env = append(os.Environ(), env)
+		h.prepare(cmd)
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return cmd.Process, nil
+	}
+}
+
+func TestHandoffReleasesStorage(t *testing.T) {
+
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "config-storage.db")
+
+	storage, err := boltstore.New("config-storage", dataFile, 0600)
+	require.NoError(t, err)
+
+	core, err := glue.New(storage)
+	require.NoError(t, err)
+	defer core.Close()
+
+	run := &implRunCommand{
+		RestartReadyTimeout: 10 * time.Second,
+		RestartDrainTimeout: time.Second,
+	}
+
+	h := newTestHandoff(t, dir)
+	defer h.Close()
+
+	require.True(t, run.handOver(core, zap.NewNop(), nil, h, startHandoffChild(h, handoffStorageEnv + "=" + dataFile)))
+}
+
+func TestHandoffDrainsAfterChildReady(t *testing.T) {
+
+	dir := t.TempDir()
+	markerFile := filepath.Join(dir, "ready.marker")
+
+	core, err := glue.New()
+	require.NoError(t, err)
+	defer core.Close()
+
+	run := &implRunCommand{
+		RestartReadyTimeout: 10 * time.Second,
+		RestartDrainTimeout: time.Second,
+	}
+
+	h := newTestHandoff(t, dir)
+	defer h.Close()
+
+	var drained, readyBefore bool
+	srv := &stubGracefulServer{shutdown: func() {
+		drained = true
+		_, err := os.Stat(markerFile)
+		readyBefore = err == nil
+	}}
+
+	require.True(t, run.handOver(core, zap.NewNop(), []sprint.Server{srv}, h, startHandoffChild(h, handoffMarkerEnv + "=" + markerFile)))
+	require.True(t, drained)
+	require.True(t, readyBefore)
+}
+
+func TestHandoffChildExitedOnLockedStorage(t *testing.T) {
+
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "config-storage.db")
+
+	// held outside of the core context, therefore not released
+	storage, err := boltstore.New("config-storage", dataFile, 0600)
+	require.NoError(t, err)
+	defer storage.Destroy()
+
+	core, err := glue.New()
+	require.NoError(t, err)
+	defer core.Close()
+
+	run := &implRunCommand{
+		RestartReadyTimeout: 10 * time.Second,
+		RestartDrainTimeout: time.Second,
+	}
+
+	h := newTestHandoff(t, dir)
+	defer h.Close()
+
+	require.False(t, run.handOver(core, zap.NewNop(), nil, h, startHandoffChild(h, handoffStorageEnv + "=" + dataFile)))
+}
@@ -15,15 +15,22 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/server"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/codeallergy/store"
 	"go.uber.org/zap"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
 type implRunCommand struct {
@@ -38,9 +45,16 @@ type implRunCommand struct {
 	LogDirPerm     os.FileMode   `value:"application.perm.log.dir,default=-rwxrwxr-x"`
 	LogFilePerm    os.FileMode   `value:"application.perm.log.file,default=-rw-rw-r--"`
 
+	RunDir                string         `value:"application.run.dir,default="`
+	GracefulRestart       bool           `value:"application.restart.graceful,default=false"`
+	RestartReadyTimeout   time.Duration  `value:"application.restart.ready-timeout,default=30s"`
+	RestartDrainTimeout   time.Duration  `value:"application.restart.drain-timeout,default=30s"`
+
 	startupLog  *log.Logger
 	logFile     *os.File
 	logWriter   io.Writer
+
+	handedOff   bool
 }
 
 func RunCommand() sprint.Command {
@@ -52,7 +66,7 @@ func (t *implRunCommand) BeanName() string {
 }
 
 func (t *implRunCommand) Desc() string {
-	return "run server"
+	return "run server, on graceful restart the old process serves until the new one requests the storage locks, then drains"
 }
 
 func (t *implRunCommand) createLogFile() (string, error) {
@@ -109,6 +123,11 @@ func (t *implRunCommand) Destroy() error {
 
 func (t *implRunCommand) Run(args []string) (err error) {
 
+	// the parent process of graceful restart keeps serving until the storages are requested
+	if _, err := util.NotifyRestartRelease(); err != nil {
+		t.lazyStartupLog().Printf("Restart release notification error, %v\n", err)
+	}
+
 	beans := t.CoreScanner.CoreBeans()
 	if t.ApplicationFlags.Verbose() {
 		verbose := glue.Verbose{ Log: t.lazyStartupLog() }
@@ -134,7 +153,15 @@ func (t *implRunCommand) Run(args []string) (err error) {
 		}
 	}()
 	
-	err = runServers(t.Application, core, logger)
+	var handoff func([]sprint.Server) bool
+	if t.GracefulRestart {
+		handoff = func(servers []sprint.Server) bool {
+			t.handedOff = t.handoff(core, logger, servers)
+			return t.handedOff
+		}
+	}
+
+	err = runServers(t.Application, core, logger, handoff)
 	if err != nil {
 		logger.Error("ApplicationEnded", zap.Bool("restarting", t.Application.Restarting()), zap.Strings("env", t.SystemEnvironmentPropertyResolver.Environ(false)), zap.Error(err))
 	} else {
@@ -150,7 +177,7 @@ func (t *implRunCommand) Run(args []string) (err error) {
 
 	logger.Sync()
 
-	if t.Application.Restarting() {
+	if t.Application.Restarting() && !t.handedOff {
 		logger.Info("ApplicationRestarting")
 		err = t.StartCommand.Start(logger, true)
 		if err != nil {
@@ -163,6 +190,120 @@ func (t *implRunCommand) Run(args []string) (err error) {
 	return
 }
 
+/**
+	Passes listeners to the new process and keeps serving until the new process asks for the storages or is ready.
+	Storages are locked by the process that opened them and both processes can not hold them, therefore the new process
+	sends RELEASE=1 before it opens the storages, then this process drains requests and closes the core storages,
+	incoming connections wait in the listen backlog until the new process is serving.
+	Badger storages give up opening after '<storage>.open-timeout', it should be longer than the drain timeout.
+*/
+
+func (t *implRunCommand) handoff(core glue.Context, logger *zap.Logger, servers []sprint.Server) bool {
+
+	runDir := t.RunDir
+	if runDir == "" {
+		runDir = filepath.Join(t.Application.ApplicationDir(), "run")
+	}
+
+	h, err := newListenerHandoff(runDir, t.Application.Name(), servers)
+	if err != nil {
+		logger.Error("RestartHandoff", zap.Error(err))
+		return false
+	}
+	defer h.Close()
+
+	logger.Info("RestartHandoff", zap.Int("listeners", len(h.files)))
+
+	return t.handOver(core, logger, servers, h, func() (*os.Process, error) {
+		return t.StartCommand.start(logger, true, h)
+	})
+}
+
+func (t *implRunCommand) handOver(core glue.Context, logger *zap.Logger, servers []sprint.Server, h *listenerHandoff, start func() (*os.Process, error)) bool {
+
+	child, err := start()
+	if err != nil {
+		logger.Error("RestartHandoff", zap.Error(err))
+		return false
+	}
+
+	state, err := h.waitState(child, t.RestartReadyTimeout)
+	if errors.Is(err, ErrChildExited) {
+		logger.Error("RestartHandoffFailed", zap.Error(err))
+		return false
+	}
+	if err != nil {
+		logger.Warn("RestartHandoffNotReady", zap.Int("pid", child.Pid), zap.Duration("timeout", t.RestartReadyTimeout), zap.Error(err))
+	}
+
+	t.drainServers(logger, servers)
+	releaseStorages(core, logger)
+
+	if state == util.RestartReleaseState {
+		logger.Info("RestartHandoffReleased", zap.Int("pid", child.Pid))
+
+		state, err = h.waitState(child, t.RestartReadyTimeout)
+		if errors.Is(err, ErrChildExited) {
+			logger.Error("RestartHandoffFailed", zap.Error(err))
+			return false
+		}
+		if err != nil {
+			logger.Warn("RestartHandoffNotReady", zap.Int("pid", child.Pid), zap.Duration("timeout", t.RestartReadyTimeout), zap.Error(err))
+		}
+	}
+
+	if state == util.RestartReadyState {
+		logger.Info("RestartHandoffReady", zap.Int("pid", child.Pid))
+	}
+
+	// systemd tracks the new process
+	if _, err := util.SdNotify(fmt.Sprintf("MAINPID=%d", child.Pid)); err != nil {
+		logger.Error("SdNotify", zap.String("state", "MAINPID"), zap.Error(err))
+	}
+
+	return true
+}
+
+func (t *implRunCommand) drainServers(logger *zap.Logger, servers []sprint.Server) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.RestartDrainTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, srv := range servers {
+		wg.Add(1)
+		go func(srv sprint.Server) {
+			defer wg.Done()
+			if gs, ok := srv.(api.GracefulServer); ok {
+				if err := gs.Shutdown(ctx); err != nil {
+					logger.Warn("DrainServer", zap.Strings("addrs", server.AddrStrings(gs.ListenAddresses())), zap.Error(err))
+				}
+			} else {
+				srv.Stop()
+			}
+		}(srv)
+	}
+	wg.Wait()
+
+	logger.Info("RestartHandoffDrained", zap.Int("servers", len(servers)))
+}
+
+/**
+	Closes the storages of the core context to release the file locks, closing again on context close is no-op.
+*/
+
+func releaseStorages(core glue.Context, logger *zap.Logger) {
+	for _, bean := range core.Bean(store.ManagedDataStoreClass, glue.DefaultLevel) {
+		if storage, ok := bean.Object().(glue.DisposableBean); ok {
+			if err := storage.Destroy(); err != nil {
+				logger.Error("ReleaseStorage", zap.String("storage", bean.Name()), zap.Error(err))
+			} else {
+				logger.Info("ReleaseStorage", zap.String("storage", bean.Name()))
+			}
+		}
+	}
+}
+
 func findZapLogger(core glue.Context) (*zap.Logger, bool) {
 	list := core.Bean(sprint.LogClass, glue.DefaultLevel)
 	if len(list) > 0 {
@@ -22,6 +22,9 @@ var DefaultCommands = []interface{}{
 	OpenAPICommand(),
 	ConfigCommand(),
 	CertCommand(),
+	DNSCommand(),
+	DynDNSCommand(),
+	SystemdCommand(),
 	StopCommand(),
 	StatusCommand(),
 	RestartCommand(),
@@ -87,6 +87,15 @@ func (t *implStartCommand) Run(args []string) error {
 }
 
 func (t *implStartCommand) Start(logger *zap.Logger, restart bool) error {
+	_, err := t.start(logger, restart, nil)
+	return err
+}
+
+/**
+	Starts the child process, passes listeners to it on graceful restart
+*/
+
+func (t *implStartCommand) start(logger *zap.Logger, restart bool, handoff *listenerHandoff) (*os.Process, error) {
 
 	runDir := t.RunDir
 	if runDir == "" {
@@ -96,7 +105,7 @@ func (t *implStartCommand) Start(logger *zap.Logger, restart bool) error {
 
 	if _, err := os.Stat(runDir); err != nil {
 		if err = os.MkdirAll(runDir, t.RunDirPerm); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -106,15 +115,15 @@ func (t *implStartCommand) Start(logger *zap.Logger, restart bool) error {
 	if !restart && pidFileExist {
 		pidContent, err := ioutil.ReadFile(pidFile)
 		if err != nil {
-			return errors.Errorf("io error on '%s', %v", pidFile, err)
+			return nil, errors.Errorf("io error on '%s', %v", pidFile, err)
 		}
 		pid, err := strconv.ParseInt(strings.TrimSpace(string(pidContent)), 10, 64)
 		if err != nil {
-			return errors.Errorf("invalid pid number in '%s', %v", pidFile, err)
+			return nil, errors.Errorf("invalid pid number in '%s', %v", pidFile, err)
 		}
 		process, err := os.FindProcess(int(pid))
 		if err == nil && process.Signal(syscall.Signal(0)) == nil {
-			return errors.Errorf("found already running process under pid '%d' from file '%s'", process.Pid, pidFile)
+			return nil, errors.Errorf("found already running process under pid '%d' from file '%s'", process.Pid, pidFile)
 		}
 	}
 
@@ -175,10 +184,13 @@ func (t *implStartCommand) Start(logger *zap.Logger, restart bool) error {
 	args = append(args, "run")
 	cmd := exec.Command(nextExePath, args...)
 	cmd.Env = append(os.Environ(), t.SystemEnvironmentPropertyResolver.Environ(true)...)
+	if handoff != nil {
+		handoff.prepare(cmd)
+	}
 	logger.Info("Run", zap.String("binary", nextExePath), zap.Strings("args", args))
 
 	if err := cmd.Start(); err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info("Daemon", zap.Int("pid", cmd.Process.Pid))
@@ -195,13 +207,18 @@ func (t *implStartCommand) Start(logger *zap.Logger, restart bool) error {
 		}
 	}
 
+	if handoff != nil {
+		// parent process waits for the readiness
+		return cmd.Process, nil
+	}
+
 	// detach child process
 	err = cmd.Process.Release()
 	if err != nil {
 		logger.Error("ProcessRelease", zap.Error(err))
 	}
 
-	return err
+	return nil, err
 }
 
 func (t *implStartCommand) executableNext(current string) string {
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type implSystemdCommand struct {
+	Application      sprint.Application      `inject`
+	Properties       glue.Properties         `inject`
+
+	RunDir           string       `value:"application.run.dir,default="`
+}
+
+func SystemdCommand() sprint.Command {
+	return &implSystemdCommand{}
+}
+
+func (t *implSystemdCommand) BeanName() string {
+	return "systemd"
+}
+
+func (t *implSystemdCommand) Desc() string {
+	return "systemd commands: [unit]"
+}
+
+func (t *implSystemdCommand) Run(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("systemd command needs argument, %s", t.Desc())
+	}
+	cmd := args[0]
+	args = args[1:]
+
+	switch cmd {
+	case "unit":
+		return t.unit(args)
+	default:
+		return errors.Errorf("unknown systemd command '%s', %s", cmd, t.Desc())
+	}
+}
+
+/**
+	Generates service and socket units, prints them or writes to the given directory.
+*/
+
+func (t *implSystemdCommand) unit(args []string) error {
+
+	service, err := t.serviceUnit()
+	if err != nil {
+		return err
+	}
+	socket := t.socketUnit()
+
+	serviceFile := fmt.Sprintf("%s.service", t.Application.Name())
+	socketFile := fmt.Sprintf("%s.socket", t.Application.Name())
+
+	if len(args) == 0 {
+		fmt.Printf("# %s\n%s\n# %s\n%s", serviceFile, service, socketFile, socket)
+		return nil
+	}
+
+	dir := args[0]
+	for fileName, content := range map[string]string{ serviceFile: service, socketFile: socket } {
+		filePath := filepath.Join(dir, fileName)
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return errors.Errorf("can not write file '%s', %v", filePath, err)
+		}
+		fmt.Printf("Written %s\n", filePath)
+	}
+
+	fmt.Printf("Enable by: systemctl daemon-reload && systemctl enable --now %s\n", socketFile)
+	return nil
+}
+
+func (t *implSystemdCommand) serviceUnit() (string, error) {
+
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	out.WriteString("[Unit]\n")
+	out.WriteString(fmt.Sprintf("Description=%s\n", t.Application.Name()))
+	out.WriteString(fmt.Sprintf("Requires=%s.socket\n", t.Application.Name()))
+	out.WriteString("After=network-online.target\n")
+	out.WriteString("Wants=network-online.target\n")
+	out.WriteString("\n[Service]\n")
+	out.WriteString("Type=notify\n")
+	out.WriteString("NotifyAccess=main\n")
+	out.WriteString(fmt.Sprintf("User=%s\n", u.Username))
+	out.WriteString(fmt.Sprintf("WorkingDirectory=%s\n", t.Application.ApplicationDir()))
+	out.WriteString(fmt.Sprintf("EnvironmentFile=-/etc/default/%s\n", t.Application.Name()))
+	out.WriteString(fmt.Sprintf("ExecStart=%s run\n", executable))
+	out.WriteString("Restart=on-failure\n")
+	out.WriteString("WatchdogSec=30s\n")
+	out.WriteString("\n[Install]\n")
+	out.WriteString("WantedBy=multi-user.target\n")
+	return out.String(), nil
+}
+
+func (t *implSystemdCommand) socketUnit() string {
+
+	var out strings.Builder
+	out.WriteString("[Unit]\n")
+	out.WriteString(fmt.Sprintf("Description=%s sockets\n", t.Application.Name()))
+	out.WriteString("\n[Socket]\n")
+	for _, addr := range t.listenStreams() {
+		out.WriteString(fmt.Sprintf("ListenStream=%s\n", addr))
+	}
+	out.WriteString("SocketMode=0660\n")
+	out.WriteString(fmt.Sprintf("Service=%s.service\n", t.Application.Name()))
+	out.WriteString("\n[Install]\n")
+	out.WriteString("WantedBy=sockets.target\n")
+	return out.String()
+}
+
+/**
+	Collects listen addresses and unix sockets of all servers, the servers take them back by address on start.
+*/
+
+func (t *implSystemdCommand) listenStreams() []string {
+
+	runDir := t.RunDir
+	if runDir == "" {
+		runDir = filepath.Join(t.Application.ApplicationDir(), "run")
+	}
+
+	var list []string
+	keys := t.Properties.Keys()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := t.Properties.GetString(key, "")
+		switch {
+		case strings.HasSuffix(key, ".listen-address"):
+			for _, addr := range strings.Split(value, ";") {
+				addr = strings.TrimSpace(addr)
+				if strings.HasPrefix(addr, ":") {
+					// systemd listens port on all interfaces
+					addr = addr[1:]
+				}
+				if addr != "" {
+					list = append(list, addr)
+				}
+			}
+		case strings.HasSuffix(key, ".unix-socket") && value != "":
+			if !filepath.IsAbs(value) {
+				value = filepath.Join(runDir, value)
+			}
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"github.com/codeallergy/sprintframework/pkg/server"
+	"github.com/codeallergy/sprintframework/pkg/util"
 	"github.com/codeallergy/sprint"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -29,11 +31,76 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 )
 
 func doWithControlClient(parent glue.Context, cb func(sprint.ControlClient) error) error {
 
+	return doInClient(parent, func(ctx glue.Context) error {
+
+		list := ctx.Bean(sprint.ControlClientClass, glue.DefaultLevel)
+		if len(list) != 1 {
+			return errors.Errorf("client context should have one sprint.ControlClient inference, but found '%d'", len(list))
+		}
+		bean := list[0]
+
+		if client, ok := bean.Object().(sprint.ControlClient); ok {
+			return cb(client)
+		} else {
+			return errors.Errorf("invalid object '%v' found instead of sprint.ControlClient in client context", bean.Class())
+		}
+	})
+
+}
+
+func doWithDNSClient(parent glue.Context, cb func(api.DNSClient) error) error {
+
+	return doInClient(parent, func(ctx glue.Context) error {
+
+		list := ctx.Bean(api.DNSClientClass, glue.DefaultLevel)
+		if len(list) == 0 {
+			return ErrDNSClientNotFound
+		}
+		if len(list) != 1 {
+			return errors.Errorf("client context should have one api.DNSClient inference, but found '%d'", len(list))
+		}
+		bean := list[0]
+
+		if client, ok := bean.Object().(api.DNSClient); ok {
+			return cb(client)
+		} else {
+			return errors.Errorf("invalid object '%v' found instead of api.DNSClient in client context", bean.Class())
+		}
+	})
+
+}
+
+func doWithCertClient(parent glue.Context, cb func(api.CertClient) error) error {
+
+	return doInClient(parent, func(ctx glue.Context) error {
+
+		list := ctx.Bean(api.CertClientClass, glue.DefaultLevel)
+		if len(list) == 0 {
+			return ErrCertClientNotFound
+		}
+		if len(list) != 1 {
+			return errors.Errorf("client context should have one api.CertClient inference, but found '%d'", len(list))
+		}
+		bean := list[0]
+
+		if client, ok := bean.Object().(api.CertClient); ok {
+			return cb(client)
+		} else {
+			return errors.Errorf("invalid object '%v' found instead of api.CertClient in client context", bean.Class())
+		}
+	})
+
+}
+
+func doInClient(parent glue.Context, cb func(glue.Context) error) error {
+
 	var verbose bool
 	list := parent.Bean(sprint.ApplicationFlagsClass, glue.DefaultLevel)
 	if len(list) > 0 {
@@ -66,18 +133,7 @@ func doWithControlClient(parent glue.Context, cb func(sprint.ControlClient) erro
 	}
 	defer ctx.Close()
 
-	list = ctx.Bean(sprint.ControlClientClass, glue.DefaultLevel)
-	if len(list) != 1 {
-		return errors.Errorf("client context should have one sprint.ControlClient inference, but found '%d'", len(list))
-	}
-	bean = list[0]
-
-	if client, ok := bean.Object().(sprint.ControlClient); ok {
-		return cb(client)
-	} else {
-		return errors.Errorf("invalid object '%v' found instead of sprint.ControlClient in client context", bean.Class())
-	}
-
+	return cb(ctx)
 }
 
 func doWithServers(core glue.Context, cb func([]sprint.Server) error) (err error) {
@@ -145,7 +201,7 @@ func doWithServers(core glue.Context, cb func([]sprint.Server) error) (err error
 
 		for i, bean := range ctx.Bean(sprint.HttpServerClass, glue.DefaultLevel) {
 			if srv, ok := bean.Object().(*http.Server); ok {
-				s := server.NewHttpServer(srv)
+				s := server.NewHttpServer(bean.Name(), srv)
 				if err := ctx.Inject(s); err != nil {
 					return errors.Errorf("injection error for server '%s' of *http.Server on position %d in server context, %v", srv.Addr, i, err)
 				}
@@ -160,7 +216,11 @@ func doWithServers(core glue.Context, cb func([]sprint.Server) error) (err error
 	return cb(serverList)
 }
 
-func runServers(application sprint.Application, core glue.Context, log *zap.Logger) error {
+/**
+	Runs servers until application shutdown, the handoff function passes listeners to the new process on restart and returns true if servers are drained by it.
+*/
+
+func runServers(application sprint.Application, core glue.Context, log *zap.Logger, handoff func([]sprint.Server) bool) error {
 
 	return doWithServers(core, func(servers []sprint.Server) error {
 
@@ -202,6 +262,20 @@ func runServers(application sprint.Application, core glue.Context, log *zap.Logg
 		}
 		log.Info("ApplicationStarted", zap.Int("Servers", cnt))
 
+		// notify the parent process on graceful restart
+		if _, err := util.NotifyRestartReady(); err != nil {
+			log.Error("NotifyRestartReady", zap.Error(err))
+		}
+
+		// notify systemd service manager, no-op if not running under systemd
+		if ok, err := util.SdNotify("READY=1"); err != nil {
+			log.Error("SdNotify", zap.String("state", "READY=1"), zap.Error(err))
+		} else if ok {
+			if interval := util.SdWatchdogInterval(); interval > 0 {
+				go sdWatchdog(c, interval / 2, log)
+			}
+		}
+
 		go func() {
 
 			signalCh := make(chan os.Signal, 10)
@@ -231,12 +305,24 @@ func runServers(application sprint.Application, core glue.Context, log *zap.Logg
 			}
 
 			log.Info("StopApplication", zap.String("signal", signal.String()))
-			total := 0
-			for _, server := range boundServers {
-				server.Stop()
-				total++
+			if application.Restarting() && handoff != nil && handoff(boundServers) {
+				log.Info("ServersHandedOff", zap.Int("cnt", len(boundServers)))
+			} else {
+				if _, err := util.SdNotify("STOPPING=1"); err != nil {
+					log.Error("SdNotify", zap.String("state", "STOPPING=1"), zap.Error(err))
+				}
+				// servers drain requests in parallel, each one with own shutdown timeout
+				var wg sync.WaitGroup
+				for _, server := range boundServers {
+					wg.Add(1)
+					go func(server sprint.Server) {
+						defer wg.Done()
+						server.Stop()
+					}(server)
+				}
+				wg.Wait()
+				log.Info("ServersStopped", zap.Int("cnt", len(boundServers)))
 			}
-			log.Info("ServersStopped", zap.Int("cnt", total))
 			log.Sync()
 			cancel()
 
@@ -247,6 +333,22 @@ func runServers(application sprint.Application, core glue.Context, log *zap.Logg
 
 }
 
+func sdWatchdog(ctx context.Context, interval time.Duration, log *zap.Logger) {
+	log.Info("SdWatchdog", zap.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := util.SdNotify("WATCHDOG=1"); err != nil {
+				log.Error("SdNotify", zap.String("state", "WATCHDOG=1"), zap.Error(err))
+			}
+		}
+	}
+}
+
 func doInCore(parent glue.Context, withBean interface{}, cb func(core glue.Context) error) error {
 
 	list := parent.Bean(sprint.CoreScannerClass, glue.DefaultLevel)
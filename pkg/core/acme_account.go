@@ -0,0 +1,496 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/codeallergy/sealmod"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/go-acme/lego/v4/acme"
+	legoapi "github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/lego"
+	"go.opentelemetry.io/otel/attribute"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+	"io/ioutil"
+	"net/http"
+)
+
+// Pending account key stored during the rollover, so the new key is never lost
+// if the ACME server accepted the key change but the account was not saved.
+const acmeRolloverPrefix = "acme-rollover"
+
+// Replaces the key of the ACME account by RFC 8555 section 7.3.5 key change.
+// The stored account is replaced only after the ACME server confirmed the new key.
+func (t *implCertificateService) RolloverAcmeAccount(email string) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("rollover acme account '%s', recover: %v", email, r)
+		}
+	}()
+
+	account, err := t.CertificateRepository.FindAccount(email)
+	if err != nil {
+		return err
+	}
+
+	if account.Email == "" {
+		return errors.Errorf("acme account '%s' not found", email)
+	}
+
+	oldKey, err := t.parseAcmeAccountKey(account)
+	if err != nil {
+		return err
+	}
+
+	pending, err := t.findPendingAcmeAccount(email)
+	if err != nil {
+		return err
+	}
+
+	if pending != nil {
+		return t.recoverPendingAcmeAccount(pending)
+	}
+
+	sealer, err := t.SealService.IssueSealer("RSA", 2048)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := sealer.EncodePublicKey()
+	if err != nil {
+		return err
+	}
+
+	privKey, err := sealer.EncodePrivateKey()
+	if err != nil {
+		return err
+	}
+
+	newKey, ok := sealer.PrivateKey().(crypto.Signer)
+	if !ok {
+		return errors.Errorf("unsupported acme account key type %T", sealer.PrivateKey())
+	}
+
+	newAccount := &sprintpb.AcmeAccount{
+		Email:      email,
+		PublicKey:  []byte(pubKey),
+		PrivateKey: []byte(privKey),
+	}
+
+	if err := t.savePendingAcmeAccount(newAccount); err != nil {
+		return errors.Wrapf(err, "save pending acme account '%s'", email)
+	}
+
+	user := &sprint.AcmeUser{
+		Email:      email,
+		PrivateKey: oldKey,
+	}
+
+	config := t.newAcmeConfig(user)
+
+	var accountURL string
+	logContent := t.doAcmeCall(context.Background(), "AcmeAccountRollover", []attribute.KeyValue{attribute.String("acme.email", email)}, func() error {
+
+		var client *lego.Client
+		client, err = lego.NewClient(config)
+		if err != nil {
+			return err
+		}
+
+		reg, e := client.Registration.ResolveAccountByKey()
+		if e != nil {
+			err = e
+			return err
+		}
+
+		accountURL = reg.URI
+		err = acmeKeyChange(config, accountURL, oldKey, newKey)
+		return err
+	})
+
+	if err != nil {
+		t.Log.Warn("AcmeAccountRollover", zap.String("email", email), zap.String("account", accountURL), zap.String("log", string(logContent)), zap.Error(err))
+		// the server could have accepted the key change if the response was lost
+		if !isAcmeRejection(err) {
+			return errors.Wrapf(err, "rollover acme account '%s', new key is kept as pending, run rollover again to recover", email)
+		}
+		if e := t.removePendingAcmeAccount(email); e != nil {
+			t.Log.Error("AcmeAccountRolloverCleanup", zap.String("email", email), zap.Error(e))
+		}
+		return err
+	}
+
+	if err := t.CertificateRepository.SaveAccount(newAccount); err != nil {
+		t.Log.Error("AcmeAccountRollover", zap.String("email", email), zap.String("account", accountURL), zap.String("pending", "kept"), zap.Error(err))
+		return errors.Wrapf(err, "save acme account '%s', new key is kept as pending, run rollover again to recover", email)
+	}
+
+	if err := t.removePendingAcmeAccount(email); err != nil {
+		t.Log.Warn("AcmeAccountRolloverCleanup", zap.String("email", email), zap.Error(err))
+	}
+
+	t.Log.Info("AcmeAccountRollover",
+		zap.String("email", email),
+		zap.String("account", accountURL),
+		zap.String("oldKey", keyThumbprint(oldKey)),
+		zap.String("newKey", keyThumbprint(newKey)),
+		zap.String("log", string(logContent)))
+
+	return nil
+}
+
+// Deactivates the ACME account on the server and removes it from the repository.
+// Deactivation is not reversible, next certificate request creates a new account.
+func (t *implCertificateService) DeactivateAcmeAccount(email string) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("deactivate acme account '%s', recover: %v", email, r)
+		}
+	}()
+
+	account, err := t.CertificateRepository.FindAccount(email)
+	if err != nil {
+		return err
+	}
+
+	if account.Email == "" {
+		return errors.Errorf("acme account '%s' not found", email)
+	}
+
+	key, err := t.parseAcmeAccountKey(account)
+	if err != nil {
+		return err
+	}
+
+	user := &sprint.AcmeUser{
+		Email:      email,
+		PrivateKey: key,
+	}
+
+	config := t.newAcmeConfig(user)
+
+	logContent := t.doAcmeCall(context.Background(), "AcmeAccountDeactivate", []attribute.KeyValue{attribute.String("acme.email", email)}, func() error {
+
+		var client *lego.Client
+		client, err = lego.NewClient(config)
+		if err != nil {
+			return err
+		}
+
+		reg, e := client.Registration.ResolveAccountByKey()
+		if e != nil {
+			err = e
+			return err
+		}
+
+		user.Registration = wrapAcmeResource(reg)
+		err = client.Registration.DeleteRegistration()
+		return err
+	})
+
+	var accountURL string
+	if user.Registration != nil {
+		accountURL = user.Registration.URI
+	}
+
+	if err != nil {
+		t.Log.Warn("AcmeAccountDeactivate", zap.String("email", email), zap.String("account", accountURL), zap.String("log", string(logContent)), zap.Error(err))
+		return err
+	}
+
+	t.Log.Info("AcmeAccountDeactivate",
+		zap.String("email", email),
+		zap.String("account", accountURL),
+		zap.String("key", keyThumbprint(key)),
+		zap.String("log", string(logContent)))
+
+	if err := t.CertificateRepository.DeleteAccount(email); err != nil {
+		return errors.Wrapf(err, "delete deactivated acme account '%s'", email)
+	}
+
+	return nil
+}
+
+func (t *implCertificateService) newAcmeConfig(user *sprint.AcmeUser) *lego.Config {
+	config := lego.NewConfig(acmeUserAdapter{user})
+	config.UserAgent = fmt.Sprintf("%s/%s", t.Application.Name(), t.Application.Version())
+	if t.AcmeDirectory != "" {
+		config.CADirURL = t.AcmeDirectory
+	}
+	return config
+}
+
+// Problem document with the client error status is the definitive rejection of the request,
+// transport errors and server errors leave the outcome unknown.
+func isAcmeRejection(err error) bool {
+	var problem *acme.ProblemDetails
+	if errors.As(err, &problem) {
+		return problem.HTTPStatus >= http.StatusBadRequest && problem.HTTPStatus < http.StatusInternalServerError
+	}
+	return false
+}
+
+func (t *implCertificateService) parseAcmeAccountKey(account *sprintpb.AcmeAccount) (crypto.Signer, error) {
+
+	sealer, err := t.SealService.Sealer(
+		sealmod.WithEncodedRSAPublicKey(string(account.PublicKey)),
+		sealmod.WithEncodedRSAPrivateKey(string(account.PrivateKey)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse acme account '%s'", account.Email)
+	}
+
+	key, ok := sealer.PrivateKey().(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported key type %T of acme account '%s'", sealer.PrivateKey(), account.Email)
+	}
+
+	return key, nil
+}
+
+// Previous rollover was interrupted after the pending key was stored. If the ACME server
+// resolves the account by the pending key, then the key change was accepted and the pending
+// account is promoted, if the server rejects the pending key, then it is discarded.
+func (t *implCertificateService) recoverPendingAcmeAccount(pending *sprintpb.AcmeAccount) (err error) {
+
+	key, err := t.parseAcmeAccountKey(pending)
+	if err != nil {
+		return err
+	}
+
+	config := t.newAcmeConfig(&sprint.AcmeUser{
+		Email:      pending.Email,
+		PrivateKey: key,
+	})
+
+	var accountURL string
+	logContent := t.doAcmeCall(context.Background(), "AcmeAccountRecover", []attribute.KeyValue{attribute.String("acme.email", pending.Email)}, func() error {
+
+		var client *lego.Client
+		client, err = lego.NewClient(config)
+		if err != nil {
+			return err
+		}
+
+		reg, e := client.Registration.ResolveAccountByKey()
+		if e != nil {
+			err = e
+			return err
+		}
+
+		accountURL = reg.URI
+		return nil
+	})
+
+	if err != nil {
+		if !isAcmeRejection(err) {
+			t.Log.Warn("AcmeAccountRolloverRecover", zap.String("email", pending.Email), zap.String("log", string(logContent)), zap.Error(err))
+			return errors.Wrapf(err, "recover pending key of acme account '%s'", pending.Email)
+		}
+		t.Log.Warn("AcmeAccountRolloverDiscard", zap.String("email", pending.Email), zap.String("log", string(logContent)), zap.Error(err))
+		if err := t.removePendingAcmeAccount(pending.Email); err != nil {
+			return err
+		}
+		return errors.Errorf("pending key of acme account '%s' was not accepted by server and discarded, run rollover again", pending.Email)
+	}
+
+	if err := t.CertificateRepository.SaveAccount(pending); err != nil {
+		return err
+	}
+
+	t.Log.Info("AcmeAccountRolloverRecover",
+		zap.String("email", pending.Email),
+		zap.String("account", accountURL),
+		zap.String("newKey", keyThumbprint(key)))
+
+	return t.removePendingAcmeAccount(pending.Email)
+}
+
+func (t *implCertificateService) findPendingAcmeAccount(email string) (*sprintpb.AcmeAccount, error) {
+	account := new(sprintpb.AcmeAccount)
+	err := t.CertificateRepository.Backend().Get(context.Background()).ByKey("%s:%s:%s", CertBucket, acmeRolloverPrefix, email).ToProto(account)
+	if err != nil {
+		return nil, err
+	}
+	if account.Email == "" {
+		return nil, nil
+	}
+	return account, nil
+}
+
+func (t *implCertificateService) savePendingAcmeAccount(account *sprintpb.AcmeAccount) error {
+	return t.CertificateRepository.Backend().Set(context.Background()).ByKey("%s:%s:%s", CertBucket, acmeRolloverPrefix, account.Email).Proto(account)
+}
+
+func (t *implCertificateService) removePendingAcmeAccount(email string) error {
+	return t.CertificateRepository.Backend().Remove(context.Background()).ByKey("%s:%s:%s", CertBucket, acmeRolloverPrefix, email).Do()
+}
+
+// Sends the keyChange request, the inner JWS is signed by the new key and carries it as jwk,
+// the outer JWS is signed by the old key and identifies the account by kid.
+func acmeKeyChange(config *lego.Config, accountURL string, oldKey, newKey crypto.Signer) error {
+
+	core, err := legoapi.New(config.HTTPClient, config.UserAgent, config.CADirURL, accountURL, oldKey)
+	if err != nil {
+		return err
+	}
+
+	dir := core.GetDirectory()
+	if dir.KeyChangeURL == "" {
+		return errors.Errorf("acme server '%s' does not support key change", config.CADirURL)
+	}
+
+	innerSigner, err := newJoseSigner(newKey, "", dir.KeyChangeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(struct {
+		Account string          `json:"account"`
+		OldKey  jose.JSONWebKey `json:"oldKey"`
+	}{
+		Account: accountURL,
+		OldKey:  jose.JSONWebKey{Key: oldKey.Public()},
+	})
+	if err != nil {
+		return err
+	}
+
+	inner, err := innerSigner.Sign(payload)
+	if err != nil {
+		return errors.Wrap(err, "sign inner jws")
+	}
+
+	nonces := &acmeNonceSource{client: config.HTTPClient, url: dir.NewNonceURL, userAgent: config.UserAgent}
+
+	outerSigner, err := newJoseSigner(oldKey, accountURL, dir.KeyChangeURL, nonces)
+	if err != nil {
+		return err
+	}
+
+	outer, err := outerSigner.Sign([]byte(inner.FullSerialize()))
+	if err != nil {
+		return errors.Wrap(err, "sign outer jws")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, dir.KeyChangeURL, bytes.NewReader([]byte(outer.FullSerialize())))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	req.Header.Set("User-Agent", config.UserAgent)
+
+	resp, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		content, _ := ioutil.ReadAll(resp.Body)
+		problem := &acme.ProblemDetails{}
+		if json.Unmarshal(content, problem) == nil && problem.Type != "" {
+			problem.HTTPStatus = resp.StatusCode
+			return problem
+		}
+		return errors.Errorf("key change failed with status %d: %s", resp.StatusCode, string(content))
+	}
+
+	return nil
+}
+
+func newJoseSigner(key crypto.Signer, kid, url string, nonces jose.NonceSource) (jose.Signer, error) {
+
+	var alg jose.SignatureAlgorithm
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			alg = jose.ES256
+		case elliptic.P384():
+			alg = jose.ES384
+		default:
+			return nil, errors.Errorf("unsupported curve %s", k.Curve.Params().Name)
+		}
+	default:
+		return nil, errors.Errorf("unsupported key type %T", key)
+	}
+
+	options := &jose.SignerOptions{
+		NonceSource: nonces,
+		ExtraHeaders: map[jose.HeaderKey]interface{}{
+			"url": url,
+		},
+	}
+
+	signingKey := jose.SigningKey{Algorithm: alg, Key: key}
+	if kid != "" {
+		signingKey.Key = jose.JSONWebKey{Key: key, KeyID: kid, Algorithm: string(alg)}
+	} else {
+		options.EmbedJWK = true
+	}
+
+	return jose.NewSigner(signingKey, options)
+}
+
+type acmeNonceSource struct {
+	client    *http.Client
+	url       string
+	userAgent string
+}
+
+func (t *acmeNonceSource) Nonce() (string, error) {
+
+	req, err := http.NewRequest(http.MethodHead, t.url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "get acme nonce")
+	}
+	resp.Body.Close()
+
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.Errorf("server '%s' did not respond with a nonce", t.url)
+	}
+	return nonce, nil
+}
+
+func keyThumbprint(key crypto.Signer) string {
+	jwk := jose.JSONWebKey{Key: key.Public()}
+	sum, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(sum)
+}
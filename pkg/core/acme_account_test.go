@@ -0,0 +1,276 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/codeallergy/cachestore"
+	"github.com/codeallergy/sealmod"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type stubApplication struct {
+	sprint.Application
+}
+
+func (t *stubApplication) Name() string {
+	return "test"
+}
+
+func (t *stubApplication) Version() string {
+	return "1.0.0"
+}
+
+type stubAccountRepository struct {
+	sprint.CertificateRepository
+	backend  store.DataStore
+	accounts map[string]*sprintpb.AcmeAccount
+}
+
+func (t *stubAccountRepository) FindAccount(email string) (*sprintpb.AcmeAccount, error) {
+	if account, ok := t.accounts[email]; ok {
+		return account, nil
+	}
+	return new(sprintpb.AcmeAccount), nil
+}
+
+func (t *stubAccountRepository) SaveAccount(account *sprintpb.AcmeAccount) error {
+	t.accounts[account.Email] = account
+	return nil
+}
+
+func (t *stubAccountRepository) Backend() store.DataStore {
+	return t.backend
+}
+
+/**
+Minimal ACME server that knows a single account key and implements key change.
+*/
+
+type stubAcmeServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	accountKey string // thumbprint of the current account key
+	keyChange  string // ok, reject or lost
+}
+
+func newStubAcmeServer() *stubAcmeServer {
+	s := &stubAcmeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (t *stubAcmeServer) serve(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Replay-Nonce", base64.RawURLEncoding.EncodeToString([]byte(r.URL.Path)))
+
+	switch r.URL.Path {
+	case "/dir":
+		json.NewEncoder(w).Encode(map[string]string{
+			"newNonce":   t.URL + "/nonce",
+			"newAccount": t.URL + "/new-acct",
+			"newOrder":   t.URL + "/new-order",
+			"revokeCert": t.URL + "/revoke",
+			"keyChange":  t.URL + "/key-change",
+		})
+
+	case "/nonce":
+		w.WriteHeader(http.StatusOK)
+
+	case "/new-acct":
+		key, _, err := t.parse(r)
+		if err != nil {
+			t.problem(w, http.StatusBadRequest, "malformed")
+			return
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if key != t.accountKey {
+			t.problem(w, http.StatusBadRequest, "accountDoesNotExist")
+			return
+		}
+		w.Header().Set("Location", t.URL+"/acct/1")
+		json.NewEncoder(w).Encode(map[string]string{"status": "valid"})
+
+	case "/key-change":
+		_, payload, err := t.parse(r)
+		if err != nil {
+			t.problem(w, http.StatusBadRequest, "malformed")
+			return
+		}
+		inner, err := jose.ParseSigned(string(payload))
+		if err != nil || len(inner.Signatures) == 0 || inner.Signatures[0].Protected.JSONWebKey == nil {
+			t.problem(w, http.StatusBadRequest, "malformed")
+			return
+		}
+		newKey := thumbprint(inner.Signatures[0].Protected.JSONWebKey)
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		switch t.keyChange {
+		case "reject":
+			t.problem(w, http.StatusBadRequest, "badPublicKey")
+		case "lost":
+			t.accountKey = newKey
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			t.accountKey = newKey
+			w.WriteHeader(http.StatusOK)
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+/**
+Returns the thumbprint of the jwk from the protected header and the payload of the JWS.
+*/
+
+func (t *stubAcmeServer) parse(r *http.Request) (string, []byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return "", nil, err
+	}
+	jws, err := jose.ParseSigned(string(body))
+	if err != nil {
+		return "", nil, err
+	}
+	if len(jws.Signatures) == 0 {
+		return "", nil, fmt.Errorf("no signatures")
+	}
+	var key string
+	if jwk := jws.Signatures[0].Protected.JSONWebKey; jwk != nil {
+		key = thumbprint(jwk)
+	}
+	return key, jws.UnsafePayloadWithoutVerification(), nil
+}
+
+func (t *stubAcmeServer) problem(w http.ResponseWriter, status int, kind string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "urn:ietf:params:acme:error:" + kind,
+		"status": status,
+	})
+}
+
+func thumbprint(jwk *jose.JSONWebKey) string {
+	sum, _ := jwk.Thumbprint(crypto.SHA256)
+	return base64.RawURLEncoding.EncodeToString(sum)
+}
+
+func newRolloverTest(t *testing.T) (*implCertificateService, *stubAccountRepository, *stubAcmeServer, crypto.Signer) {
+
+	server := newStubAcmeServer()
+	t.Cleanup(server.Close)
+
+	repository := &stubAccountRepository{
+		backend:  cachestore.New("test-storage"),
+		accounts: make(map[string]*sprintpb.AcmeAccount),
+	}
+
+	service := &implCertificateService{
+		Application:           &stubApplication{},
+		Log:                   zap.NewNop(),
+		CertificateRepository: repository,
+		SealService:           sealmod.SealService(),
+		AcmeDirectory:         server.URL + "/dir",
+	}
+
+	user, err := service.GetOrCreateAcmeUser("admin@example.com")
+	require.NoError(t, err)
+
+	key := user.PrivateKey.(crypto.Signer)
+	server.accountKey = keyThumbprint(key)
+	return service, repository, server, key
+}
+
+func accountKey(t *testing.T, service *implCertificateService, account *sprintpb.AcmeAccount) string {
+	key, err := service.parseAcmeAccountKey(account)
+	require.NoError(t, err)
+	return keyThumbprint(key)
+}
+
+func TestRolloverAcmeAccount(t *testing.T) {
+
+	service, repository, server, oldKey := newRolloverTest(t)
+
+	err := service.RolloverAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+
+	newKey := accountKey(t, service, repository.accounts["admin@example.com"])
+	require.NotEqual(t, keyThumbprint(oldKey), newKey)
+	require.Equal(t, server.accountKey, newKey)
+
+	pending, err := service.findPendingAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+	require.Nil(t, pending)
+}
+
+func TestRolloverAcmeAccountRejected(t *testing.T) {
+
+	service, repository, server, oldKey := newRolloverTest(t)
+	server.keyChange = "reject"
+
+	err := service.RolloverAcmeAccount("admin@example.com")
+	require.Error(t, err)
+	require.True(t, isAcmeRejection(err))
+
+	require.Equal(t, keyThumbprint(oldKey), accountKey(t, service, repository.accounts["admin@example.com"]))
+
+	pending, err := service.findPendingAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+	require.Nil(t, pending)
+}
+
+func TestRolloverAcmeAccountLostResponse(t *testing.T) {
+
+	service, repository, server, oldKey := newRolloverTest(t)
+	server.keyChange = "lost"
+
+	err := service.RolloverAcmeAccount("admin@example.com")
+	require.Error(t, err)
+	require.False(t, isAcmeRejection(err))
+
+	// the server switched to the new key, the old one is still stored
+	require.Equal(t, keyThumbprint(oldKey), accountKey(t, service, repository.accounts["admin@example.com"]))
+
+	pending, err := service.findPendingAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, pending)
+	require.Equal(t, server.accountKey, accountKey(t, service, pending))
+
+	// next rollover recovers the pending key
+	err = service.RolloverAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+	require.Equal(t, server.accountKey, accountKey(t, service, repository.accounts["admin@example.com"]))
+
+	pending, err = service.findPendingAcmeAccount("admin@example.com")
+	require.NoError(t, err)
+	require.Nil(t, pending)
+}
@@ -0,0 +1,405 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/store"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCSR        = errors.New("invalid certificate request")
+	ErrNameNotPermitted  = errors.New("name is not permitted")
+	ErrUnknownProfile    = errors.New("unknown certificate profile")
+)
+
+const issuedRecordPrefix = "issued"
+
+type implCertificateAuthority struct {
+	Log         *zap.Logger       `inject`
+
+	CertificateRepository   sprint.CertificateRepository   `inject`
+	CertificateIssueService sprint.CertificateIssueService `inject`
+
+	PermittedDomains  []string  `value:"certificate.authority.permitted-domains,default="`
+	ExcludedDomains   []string  `value:"certificate.authority.excluded-domains,default="`
+	PermittedIPs      []string  `value:"certificate.authority.permitted-ips,default="`
+	PermittedClients  []string  `value:"certificate.authority.permitted-clients,default="`
+	AllowWildcard     bool      `value:"certificate.authority.allow-wildcard,default=false"`
+	MinRsaBits        int       `value:"certificate.authority.min-rsa-bits,default=2048"`
+	MaxDays           int       `value:"certificate.authority.max-days,default=825"`
+	ServerDays        int       `value:"certificate.authority.server-days,default=395"`
+	ClientDays        int       `value:"certificate.authority.client-days,default=365"`
+
+	permittedNets  []*net.IPNet
+}
+
+func CertificateAuthority() api.CertificateAuthority {
+	return &implCertificateAuthority{}
+}
+
+func (t *implCertificateAuthority) PostConstruct() error {
+	for _, cidr := range t.PermittedIPs {
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Wrapf(err, "parse permitted ip '%s'", cidr)
+		}
+		t.permittedNets = append(t.permittedNets, ipNet)
+	}
+	return nil
+}
+
+func (t *implCertificateAuthority) SignCSR(req *pb.SignCSRRequest, username string) (resp *pb.SignCSRResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	signer := req.Signer
+	if signer == "" {
+		signer = "localhost"
+	}
+
+	profile := req.Profile
+	if profile == "" {
+		profile = "server"
+	}
+
+	csr, err := parseCSR(req.Csr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.validateCSR(csr, profile); err != nil {
+		return nil, err
+	}
+
+	days := int(req.Days)
+	if days == 0 {
+		if profile == "client" {
+			days = t.ClientDays
+		} else {
+			days = t.ServerDays
+		}
+	}
+	if days < 0 || days > t.MaxDays {
+		return nil, errors.Errorf("validity period %d days is out of range [1, %d]", days, t.MaxDays)
+	}
+
+	self, err := t.CertificateRepository.FindSelfSigner(signer)
+	if err != nil {
+		return nil, err
+	}
+	if self.Name == "" {
+		return nil, errors.Errorf("self signer '%s' not found", signer)
+	}
+
+	issuer, err := t.CertificateIssueService.LoadIssuer(self)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load self issuer '%s'", signer)
+	}
+
+	issuerCert := issuer.Certificate().Certificate()
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	skid, err := calculateSKID(csr.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.AddDate(0, 0, days)
+	if notAfter.After(issuerCert.NotAfter) {
+		notAfter = issuerCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		Subject:               csr.Subject,
+		SerialNumber:          serial,
+		SubjectKeyId:          skid,
+		NotBefore:             now,
+		NotAfter:              notAfter,
+		DNSNames:              csr.DNSNames,
+		IPAddresses:           csr.IPAddresses,
+		BasicConstraintsValid: true,
+		IsCA:                  false,
+	}
+
+	switch profile {
+	case "server":
+		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		if len(template.DNSNames) == 0 && len(template.IPAddresses) == 0 {
+			template.DNSNames = []string{csr.Subject.CommonName}
+		}
+	case "client":
+		// common name is the username, other attributes are not trusted
+		template.Subject = pkix.Name{CommonName: csr.Subject.CommonName}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuerCert, csr.PublicKey, issuer.Certificate().PrivateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	})
+
+	var chain bytes.Buffer
+	for i, ok := issuer, true; ok; i, ok = i.Parent() {
+		chain.Write(i.Certificate().CertFileContents())
+	}
+
+	var ipAddresses []string
+	for _, ip := range template.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+
+	record := &pb.IssuedCertificate{
+		Signer:      signer,
+		Serial:      hex.EncodeToString(serial.Bytes()),
+		Profile:     profile,
+		Subject:     template.Subject.String(),
+		DnsNames:    template.DNSNames,
+		IpAddresses: ipAddresses,
+		NotBefore:   template.NotBefore.Unix(),
+		NotAfter:    template.NotAfter.Unix(),
+		IssuedAt:    now.Unix(),
+		IssuedBy:    username,
+		Certificate: certPem,
+	}
+
+	err = t.CertificateRepository.Backend().Set(context.Background()).ByKey("%s:%s:%s:%s", CertBucket, issuedRecordPrefix, signer, record.Serial).Proto(record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "record issued certificate '%s'", record.Serial)
+	}
+
+	t.Log.Info("SignCSR",
+		zap.String("signer", signer),
+		zap.String("serial", record.Serial),
+		zap.String("profile", profile),
+		zap.String("subject", record.Subject),
+		zap.Strings("dnsNames", record.DnsNames),
+		zap.Strings("ipAddresses", record.IpAddresses),
+		zap.String("username", username))
+
+	return &pb.SignCSRResponse{
+		Certificate:       certPem,
+		IssuerCertificate: chain.Bytes(),
+		Serial:            record.Serial,
+		NotAfter:          record.NotAfter,
+	}, nil
+}
+
+func (t *implCertificateAuthority) ListIssued(signer string, cb func(*pb.IssuedCertificate) bool) error {
+	if signer != "" {
+		signer += ":"
+	}
+	return t.CertificateRepository.Backend().Enumerate(context.Background()).ByPrefix("%s:%s:%s", CertBucket, issuedRecordPrefix, signer).WithBatchSize(100).DoProto(func() proto.Message {
+		return new(pb.IssuedCertificate)
+	}, func(entry *store.ProtoEntry) bool {
+		if v, ok := entry.Value.(*pb.IssuedCertificate); ok {
+			return cb(v)
+		}
+		return true
+	})
+}
+
+func parseCSR(csrPem []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csrPem)
+	if block == nil || !strings.HasSuffix(block.Type, "CERTIFICATE REQUEST") {
+		return nil, errors.Wrap(ErrInvalidCSR, "PEM block not found")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidCSR, "%v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, errors.Wrapf(ErrInvalidCSR, "signature, %v", err)
+	}
+	return csr, nil
+}
+
+func (t *implCertificateAuthority) validateCSR(csr *x509.CertificateRequest, profile string) error {
+
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < t.MinRsaBits {
+			return errors.Wrapf(ErrInvalidCSR, "RSA key length %d is less than %d", pub.N.BitLen(), t.MinRsaBits)
+		}
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return errors.Wrapf(ErrInvalidCSR, "unsupported public key %T", pub)
+	}
+
+	if len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return errors.Wrap(ErrInvalidCSR, "email and URI names are not supported")
+	}
+
+	switch profile {
+	case "server":
+		if len(csr.DNSNames) == 0 && len(csr.IPAddresses) == 0 && csr.Subject.CommonName == "" {
+			return errors.Wrap(ErrInvalidCSR, "server certificate needs at least one name")
+		}
+		if len(csr.DNSNames) == 0 && len(csr.IPAddresses) == 0 {
+			if err := t.checkDomain(csr.Subject.CommonName); err != nil {
+				return err
+			}
+		}
+	case "client":
+		if csr.Subject.CommonName == "" {
+			return errors.Wrap(ErrInvalidCSR, "client certificate needs common name")
+		}
+		if len(csr.DNSNames) > 0 || len(csr.IPAddresses) > 0 {
+			return errors.Wrap(ErrInvalidCSR, "client certificate does not support DNS and IP names")
+		}
+		if err := t.checkClient(csr.Subject.CommonName); err != nil {
+			return err
+		}
+	default:
+		return errors.Wrapf(ErrUnknownProfile, "'%s'", profile)
+	}
+
+	for _, name := range csr.DNSNames {
+		if err := t.checkDomain(name); err != nil {
+			return err
+		}
+	}
+
+	for _, ip := range csr.IPAddresses {
+		if err := t.checkIP(ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *implCertificateAuthority) checkDomain(name string) error {
+
+	name = strings.ToLower(name)
+	if name == "" || strings.ContainsAny(name, " /:@") {
+		return errors.Wrapf(ErrInvalidCSR, "invalid domain name '%s'", name)
+	}
+
+	if strings.Contains(name, "*") {
+		if !t.AllowWildcard || !strings.HasPrefix(name, "*.") || strings.Count(name, "*") > 1 {
+			return errors.Wrapf(ErrNameNotPermitted, "wildcard '%s'", name)
+		}
+	}
+
+	for _, excluded := range t.ExcludedDomains {
+		if excluded != "" && matchDomain(name, excluded) {
+			return errors.Wrapf(ErrNameNotPermitted, "'%s' is excluded by '%s'", name, excluded)
+		}
+	}
+
+	// empty list permits nothing
+	for _, domain := range t.PermittedDomains {
+		if domain != "" && matchDomain(name, domain) {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrNameNotPermitted, "'%s'", name)
+}
+
+func (t *implCertificateAuthority) checkIP(ip net.IP) error {
+	for _, ipNet := range t.permittedNets {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrNameNotPermitted, "'%s'", ip.String())
+}
+
+/**
+Client common name becomes the username, allowed names are listed in format 'name;prefix*'.
+*/
+
+func (t *implCertificateAuthority) checkClient(cn string) error {
+
+	if len(cn) > 64 || strings.IndexFunc(cn, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._@-", r))
+	}) != -1 {
+		return errors.Wrapf(ErrInvalidCSR, "invalid client name '%s'", cn)
+	}
+
+	for _, client := range t.PermittedClients {
+		if client == "" {
+			continue
+		}
+		if strings.HasSuffix(client, "*") {
+			if strings.HasPrefix(cn, strings.TrimSuffix(client, "*")) {
+				return nil
+			}
+		} else if cn == client {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrNameNotPermitted, "client '%s'", cn)
+}
+
+/**
+Name constraint semantic of RFC 5280, constraint '.example.com' matches only subdomains, 'example.com' matches the domain and subdomains.
+*/
+
+func matchDomain(name, constraint string) bool {
+	constraint = strings.ToLower(constraint)
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(name, constraint)
+	}
+	return name == constraint || strings.HasSuffix(name, "."+constraint)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/url"
+	"testing"
+)
+
+func TestValidateCSR(t *testing.T) {
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	ca := &implCertificateAuthority{
+		PermittedDomains: []string{"example.com", ".internal.net"},
+		ExcludedDomains:  []string{"admin.example.com"},
+		PermittedClients: []string{"alice", "svc-*"},
+		MinRsaBits:       2048,
+	}
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
+	ca.permittedNets = []*net.IPNet{ipNet}
+
+	cases := []struct {
+		name    string
+		profile string
+		csr     *x509.CertificateRequest
+		err     error
+	}{
+		{"server", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"www.example.com"}}, nil},
+		{"server cn", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "example.com"}}, nil},
+		{"subdomain only", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"internal.net"}}, ErrNameNotPermitted},
+		{"subdomain", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"db.internal.net"}}, nil},
+		{"suffix trick", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"badexample.com"}}, ErrNameNotPermitted},
+		{"excluded", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"admin.example.com"}}, ErrNameNotPermitted},
+		{"wildcard", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"*.example.com"}}, ErrNameNotPermitted},
+		{"no names", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey}, ErrInvalidCSR},
+		{"ip", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, IPAddresses: []net.IP{net.ParseIP("10.1.2.3")}}, nil},
+		{"ip not permitted", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, IPAddresses: []net.IP{net.ParseIP("192.168.1.1")}}, ErrNameNotPermitted},
+		{"small rsa key", "server", &x509.CertificateRequest{PublicKey: &smallKey.PublicKey, DNSNames: []string{"example.com"}}, ErrInvalidCSR},
+		{"email", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"example.com"}, EmailAddresses: []string{"root@example.com"}}, ErrInvalidCSR},
+		{"uri", "server", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"example.com"}, URIs: []*url.URL{{Scheme: "spiffe", Host: "example.com"}}}, ErrInvalidCSR},
+		{"client", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "alice"}}, nil},
+		{"client prefix", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "svc-backup"}}, nil},
+		{"client not permitted", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "admin"}}, ErrNameNotPermitted},
+		{"client invalid cn", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "svc-a\nadmin"}}, ErrInvalidCSR},
+		{"client empty cn", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey}, ErrInvalidCSR},
+		{"client with dns", "client", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "alice"}, DNSNames: []string{"example.com"}}, ErrInvalidCSR},
+		{"unknown profile", "peer", &x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"example.com"}}, ErrUnknownProfile},
+	}
+
+	for _, c := range cases {
+		err := ca.validateCSR(c.csr, c.profile)
+		if c.err == nil {
+			require.NoError(t, err, c.name)
+		} else {
+			require.Equal(t, c.err, errors.Cause(err), c.name)
+		}
+	}
+}
+
+func TestValidateCSREmptyPolicy(t *testing.T) {
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ca := &implCertificateAuthority{MinRsaBits: 2048}
+
+	err = ca.validateCSR(&x509.CertificateRequest{PublicKey: &ecKey.PublicKey, DNSNames: []string{"example.com"}}, "server")
+	require.Equal(t, ErrNameNotPermitted, errors.Cause(err))
+
+	err = ca.validateCSR(&x509.CertificateRequest{PublicKey: &ecKey.PublicKey, IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}}, "server")
+	require.Equal(t, ErrNameNotPermitted, errors.Cause(err))
+
+	err = ca.validateCSR(&x509.CertificateRequest{PublicKey: &ecKey.PublicKey, Subject: pkix.Name{CommonName: "alice"}}, "client")
+	require.Equal(t, ErrNameNotPermitted, errors.Cause(err))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprintpb"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/util"
 	"go.uber.org/zap"
 	"math"
 	"math/big"
@@ -224,6 +225,14 @@ func (t *certificateIssuer) IssueInterCert(cn string) (sprint.CertificateIssuer,
 }
 
 func (t *certificateIssuer) IssueClientCert(cn string, password string) (cert sprint.IssuedCertificate, pfxData []byte, err error) {
+	return t.IssueClientCertWithRoles(cn, password, nil)
+}
+
+/**
+Issues client certificate with the authorization roles embedded in the private extension.
+*/
+
+func (t *certificateIssuer) IssueClientCertWithRoles(cn string, password string, roles []string) (cert sprint.IssuedCertificate, pfxData []byte, err error) {
 
 	cnName := strings.Replace(cn, "*", "_", -1)
 	cnName = strings.Replace(cnName, " ", "-", -1)
@@ -259,6 +268,13 @@ func (t *certificateIssuer) IssueClientCert(cn string, password string) (cert sp
 		BasicConstraintsValid: true,
 		IsCA:                  false,
 	}
+	if len(roles) > 0 {
+		ext, err := util.ClientRolesExtension(roles)
+		if err != nil {
+			return nil, nil, err
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, ext)
+	}
 	der, err := x509.CreateCertificate(rand.Reader, template, t.cert.x509Cert, key.Public(), t.cert.key)
 	if err != nil {
 		return nil, nil, err
@@ -291,6 +307,53 @@ func (t *certificateIssuer) IssueClientCert(cn string, password string) (cert sp
 	return &issuedCertificate{certContents: certPemFile.Bytes(), keyContents: keyPemFile, x509Cert: x509Cert, key: key}, pfxData, nil
 }
 
+/**
+Cross-signs the CA certificate by this issuer, the result has the same subject and public key,
+so chains built on the new certificate are trusted by clients knowing only this issuer.
+*/
+
+func (t *certificateIssuer) CrossSign(cert *x509.Certificate) ([]byte, error) {
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	notAfter := cert.NotAfter
+	if notAfter.After(t.cert.x509Cert.NotAfter) {
+		notAfter = t.cert.x509Cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		Subject:               cert.Subject,
+		SerialNumber:          serial,
+		NotBefore:             time.Now(),
+		NotAfter:              notAfter,
+		SubjectKeyId:          cert.SubjectKeyId,
+		KeyUsage:              cert.KeyUsage,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            cert.MaxPathLen,
+		MaxPathLenZero:        cert.MaxPathLenZero,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, t.cert.x509Cert, cert.PublicKey, t.cert.key)
+	if err != nil {
+		return nil, err
+	}
+
+	var certPemFile bytes.Buffer
+	err = pem.Encode(&certPemFile, &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certPemFile.Bytes(), nil
+}
+
 func (t *certificateIssuer) IssueServerCert(cn string, domains []string, ipAddresses []net.IP) (cert sprint.IssuedCertificate, err error) {
 
 	if cn == "" {
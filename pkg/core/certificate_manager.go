@@ -19,14 +19,18 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprintpb"
 	"github.com/codeallergy/sprint"
 	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/codeallergy/store"
 	"go.uber.org/zap"
 	"golang.org/x/net/idna"
+	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -48,6 +52,8 @@ var (
 	IssueGraceInterval = time.Hour
 )
 
+const renewalRecordPrefix = "renewal"
+
 type implCertificateManager struct {
 
 	Application sprint.Application `inject`
@@ -56,10 +62,20 @@ type implCertificateManager struct {
 
 	CertificateRepository sprint.CertificateRepository `inject`
 	CertificateService    sprint.CertificateService    `inject`
+	Storage               store.DataStore              `inject:"bean=config-storage"`
+
+	RenewJitter   time.Duration  `value:"certificate.renew-jitter,default=1h"`
+	RetryMin      time.Duration  `value:"certificate.retry-min,default=1m"`
+	RetryMax      time.Duration  `value:"certificate.retry-max,default=6h"`
+
+	renewBefore  time.Duration
 
 	cache    sync.Map   // key is string, value is *certState
 	renewal  sync.Map   // key is string, value is *certRenewal
 	unknown  sync.Map   // key is string, value is *certUnknown
+	domains  atomic.Value  // *domainIndex
+
+	attemptMu  sync.Mutex  // guards renewal records
 
 	zoneWatchCancel  context.CancelFunc
 }
@@ -70,6 +86,8 @@ func CertificateManager() sprint.CertificateManager {
 }
 
 func (t *implCertificateManager) PostConstruct() error {
+	t.renewBefore = t.Properties.GetDuration("certificate.renew-before", RenewBefore)
+
 	entry, err := t.CertificateRepository.FindZone("localhost")
 	if err != nil {
 		return err
@@ -94,12 +112,24 @@ func (t *implCertificateManager) PostConstruct() error {
 	s := t.getCertificate("localhost")
 	t.cache.Store("127.0.0.1", s)
 
+	if err := t.indexDomains(); err != nil {
+		return err
+	}
+
 	t.zoneWatchCancel, err = t.CertificateRepository.Watch(context.Background(), t.onZoneChangeEvent)
 	return err
 }
 
 func (t *implCertificateManager) onZoneChangeEvent(zone, event string) bool {
 	t.InvalidateCache(zone)
+	if err := t.indexDomains(); err != nil {
+		t.Log.Error("IndexDomains", zap.String("zone", zone), zap.Error(err))
+	}
+	if event == "DELETE" {
+		if err := t.removeRenewalRecord(zone); err != nil {
+			t.Log.Error("RemoveRenewalRecord", zap.String("zone", zone), zap.Error(err))
+		}
+	}
 	return true
 }
 
@@ -171,14 +201,16 @@ func (t *implCertificateManager) GetCertificate(hello *tls.ClientHelloInfo) (*tl
 		return nil, errors.Errorf("domain name '%s' contains invalid character", domain)
 	}
 
-	var zone string
-	dots := strings.Count(punycode, ".")
-	if dots <= 1 {
-		zone = punycode
-	} else {
-		zone, err = util.ToZone(punycode)
-		if err != nil {
-			return nil, err
+	zone, ok := t.findZone(punycode)
+	if !ok {
+		dots := strings.Count(punycode, ".")
+		if dots <= 1 {
+			zone = punycode
+		} else {
+			zone, err = util.ToZone(punycode)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -213,6 +245,64 @@ func (t *implCertificateManager) GetCertificate(hello *tls.ClientHelloInfo) (*tl
 
 }
 
+// domainIndex maps certificate domains to zones, wildcard domains are stored with the '*.' prefix.
+type domainIndex struct {
+	exact    map[string]string
+	wildcard map[string]string
+}
+
+func (t *implCertificateManager) indexDomains() error {
+
+	index := &domainIndex{
+		exact:    make(map[string]string),
+		wildcard: make(map[string]string),
+	}
+
+	err := t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
+		for _, domain := range entry.Domains {
+			domain = strings.ToLower(domain)
+			m := index.exact
+			if strings.HasPrefix(domain, "*.") {
+				m = index.wildcard
+			}
+			if zone, ok := m[domain]; ok && zone != entry.Zone {
+				t.Log.Warn("DomainConflict", zap.String("domain", domain), zap.String("zone", zone), zap.String("skipZone", entry.Zone))
+				continue
+			}
+			m[domain] = entry.Zone
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	t.domains.Store(index)
+	return nil
+}
+
+// findZone matches the server name by exact domain first and then by wildcard domain.
+func (t *implCertificateManager) findZone(name string) (string, bool) {
+
+	index, ok := t.domains.Load().(*domainIndex)
+	if !ok {
+		return "", false
+	}
+
+	name = strings.ToLower(name)
+	if zone, ok := index.exact[name]; ok {
+		return zone, true
+	}
+
+	if wildcard, ok := util.WildcardOf(name); ok {
+		if zone, ok := index.wildcard[wildcard]; ok {
+			return zone, true
+		}
+	}
+
+	return "", false
+}
+
 // cert returns an existing certificate either from cache or repository.
 func (t *implCertificateManager) getCertificate(zone string) *certState {
 
@@ -278,9 +368,9 @@ func (t *implCertificateManager) loadCertificate(zone string) (*tls.Certificate,
 
 	if entry.CertProvider == "self" || entry.CertProvider == "acme" {
 		if err != nil {
-			go t.startRenew(zone, time.Now())
+			go t.startRenew(zone, t.retryAt(zone))
 		} else {
-			go t.startRenew(zone, leaf.NotAfter.Add(-RenewBefore))
+			go t.startRenew(zone, t.renewAt(leaf))
 		}
 	}
 
@@ -316,6 +406,134 @@ func (t *implCertificateManager) startRenew(zone string, at time.Time) {
 	r.timer.Store(time.AfterFunc(after, r.renew))
 }
 
+/**
+Renewal starts at 2/3 of the certificate lifetime, but not later than renew-before (RenewBefore by default), minus random jitter.
+*/
+
+func (t *implCertificateManager) renewAt(leaf *x509.Certificate) time.Time {
+	before := leaf.NotAfter.Sub(leaf.NotBefore) / 3
+	if before < t.renewBefore {
+		before = t.renewBefore
+	}
+	at := leaf.NotAfter.Add(-before)
+	if t.RenewJitter > 0 {
+		at = at.Add(-time.Duration(rand.Int63n(int64(t.RenewJitter))))
+	}
+	return at
+}
+
+/**
+Exponential backoff with jitter based on the number of failed attempts.
+*/
+
+func (t *implCertificateManager) retryAt(zone string) time.Time {
+	record, err := t.loadRenewalRecord(zone)
+	if err != nil || record.Failures == 0 {
+		return time.Now()
+	}
+	if !record.Next.IsZero() {
+		return record.Next
+	}
+	return record.Attempt.Add(t.backoff(record.Failures))
+}
+
+func (t *implCertificateManager) backoff(failures int) time.Duration {
+	delay := t.RetryMin
+	for i := 1; i < failures && delay < t.RetryMax; i++ {
+		delay *= 2
+	}
+	if delay > t.RetryMax {
+		delay = t.RetryMax
+	}
+	// +/- 10% jitter
+	if jitter := int64(delay / 5); jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter) - jitter / 2)
+	}
+	return delay
+}
+
+/**
+Persistent record of the last renewal attempt of the zone
+*/
+
+type renewalRecord struct {
+	Zone      string     `json:"zone"`
+	Attempt   time.Time  `json:"attempt"`
+	Failures  int        `json:"failures"`
+	Error     string     `json:"error,omitempty"`
+	Next      time.Time  `json:"next,omitempty"`
+}
+
+func (t *implCertificateManager) loadRenewalRecord(zone string) (*renewalRecord, error) {
+	record := &renewalRecord{Zone: zone}
+	content, err := t.Storage.Get(context.Background()).ByKey("%s:%s:%s", CertBucket, renewalRecordPrefix, zone).ToBinary()
+	if err != nil || content == nil {
+		return record, err
+	}
+	err = json.Unmarshal(content, record)
+	return record, err
+}
+
+func (t *implCertificateManager) saveRenewalRecord(record *renewalRecord) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return t.Storage.Set(context.Background()).ByKey("%s:%s:%s", CertBucket, renewalRecordPrefix, record.Zone).Binary(content)
+}
+
+func (t *implCertificateManager) removeRenewalRecord(zone string) error {
+	return t.Storage.Remove(context.Background()).ByKey("%s:%s:%s", CertBucket, renewalRecordPrefix, zone).Do()
+}
+
+func (t *implCertificateManager) listRenewalRecords() ([]*renewalRecord, error) {
+	var list []*renewalRecord
+	err := t.Storage.Enumerate(context.Background()).ByPrefix("%s:%s:", CertBucket, renewalRecordPrefix).WithBatchSize(100).Do(func(entry *store.RawEntry) bool {
+		record := new(renewalRecord)
+		if err := json.Unmarshal(entry.Value, record); err == nil {
+			list = append(list, record)
+		}
+		return true
+	})
+	return list, err
+}
+
+/**
+Renews certificate of the zone and records the attempt, returns the time of the next retry on failure
+*/
+
+func (t *implCertificateManager) renewZone(zone string) (time.Time, error) {
+
+	err := t.CertificateService.RenewCertificate(zone)
+
+	t.attemptMu.Lock()
+	defer t.attemptMu.Unlock()
+
+	record, loadErr := t.loadRenewalRecord(zone)
+	if loadErr != nil {
+		t.Log.Error("LoadRenewalRecord", zap.String("zone", zone), zap.Error(loadErr))
+	}
+
+	record.Attempt = time.Now()
+	if err != nil {
+		record.Failures++
+		record.Error = err.Error()
+		record.Next = record.Attempt.Add(t.backoff(record.Failures))
+		t.Log.Error("RenewCertificate", zap.String("zone", zone), zap.Int("failures", record.Failures), zap.Time("next", record.Next), zap.Error(err))
+	} else {
+		record.Failures = 0
+		record.Error = ""
+		record.Next = time.Time{}
+		t.Log.Info("RenewCertificate", zap.String("zone", zone))
+	}
+
+	if saveErr := t.saveRenewalRecord(record); saveErr != nil {
+		t.Log.Error("SaveRenewalRecord", zap.String("zone", zone), zap.Error(saveErr))
+	}
+
+	return record.Next, err
+}
+
 func (t *implCertificateManager) Destroy() error {
 	t.renewal.Range(func(key, value interface{}) bool {
 		t.zoneWatchCancel()
@@ -365,21 +583,22 @@ func (t *implCertificateManager) issueCertificate(zone string) {
 		return
 	}
 
-	s.issueOnce.Do(func() {
-		s.issueAttempt = time.Now()
-		// long run
-		err := t.CertificateService.RenewCertificate(zone)
-		if err != nil {
-			if t.Application.IsDev() {
-				t.Log.Error("RenewCertificate", zap.String("zone", zone), zap.Error(err))
-			}
-			s.issueErr = err
-		} else {
-			// trigger reload
-			t.cache.Delete(zone)
-		}
+	if !s.startIssue() {
+		// issue in progress
+		return
+	}
 
-	})
+	// long run
+	next, err := t.renewZone(zone)
+	s.endIssue(err)
+
+	if err != nil {
+		// retry with backoff, the cache entry keeps the error
+		t.startRenew(zone, next)
+	} else {
+		// trigger reload
+		t.cache.Delete(zone)
+	}
 
 }
 
@@ -400,11 +619,36 @@ type certState struct {
 	loadOnce      sync.Once
 	loadErr       error
 
-	issueOnce     sync.Once
+	issueMu       sync.Mutex
+	issuing       bool
 	issueErr      error
 	issueAttempt  time.Time
 }
 
+func (t *certState) startIssue() bool {
+	t.issueMu.Lock()
+	defer t.issueMu.Unlock()
+	if t.issuing {
+		return false
+	}
+	t.issuing = true
+	t.issueAttempt = time.Now()
+	return true
+}
+
+func (t *certState) endIssue(err error) {
+	t.issueMu.Lock()
+	defer t.issueMu.Unlock()
+	t.issuing = false
+	t.issueErr = err
+}
+
+func (t *certState) lastIssue() (error, time.Time) {
+	t.issueMu.Lock()
+	defer t.issueMu.Unlock()
+	return t.issueErr, t.issueAttempt
+}
+
 // possible errors: ErrInvalidCertificate, ErrCertificateIssue, ErrCertificateRecentIssue, ErrCertificateNotReady
 func (t *certState) Clone() (*tls.Certificate, error) {
 
@@ -418,8 +662,8 @@ func (t *certState) Clone() (*tls.Certificate, error) {
 		}
 	}
 
-	if t.issueErr != nil {
-		if t.issueAttempt.Add(IssueGraceInterval).Before(time.Now()) {
+	if issueErr, issueAttempt := t.lastIssue(); issueErr != nil {
+		if issueAttempt.Add(IssueGraceInterval).Before(time.Now()) {
 			return nil, ErrCertificateIssueAfterGrace
 		} else {
 			return nil, ErrCertificateIssue
@@ -437,8 +681,8 @@ func (t *certState) Clone() (*tls.Certificate, error) {
 }
 
 func (t *certState) Err() error {
-	if t.issueErr != nil {
-		return errors.Errorf("issue certificate with last attempt at %v cause error %v", t.issueAttempt, t.issueErr)
+	if issueErr, issueAttempt := t.lastIssue(); issueErr != nil {
+		return errors.Errorf("issue certificate with last attempt at %v cause error %v", issueAttempt, issueErr)
 	}
 	if t.loadErr != nil {
 		return errors.Errorf("load certificate cause error %v", t.loadErr)
@@ -472,11 +716,15 @@ func (t *certRenewal) Stop() {
 func (t *certRenewal) renew() {
 	t.renewOnce.Do(func() {
 
-		err := t.manager.CertificateService.RenewCertificate(t.zone)
+		next, err := t.manager.renewZone(t.zone)
 		if err == nil {
 			t.manager.InvalidateCache(t.zone)
 		} else {
-			t.manager.startRenew(t.zone, time.Now().Add(IssueGraceInterval))
+			// release the slot, otherwise the later retry would be ignored
+			if value, ok := t.manager.renewal.Load(t.zone); ok && value == t {
+				t.manager.renewal.Delete(t.zone)
+			}
+			t.manager.startRenew(t.zone, next)
 		}
 
 	})
@@ -541,9 +789,34 @@ func (t *implCertificateManager) ExecuteCommand(cmd string, args []string) (stri
 
 	case "renewal":
 
+		scheduled := t.ListRenewal()
+		records, err := t.listRenewalRecords()
+		if err != nil {
+			return "", err
+		}
+
 		var out strings.Builder
-		for domain, at := range t.ListRenewal() {
-			out.WriteString(fmt.Sprintf("%s renewal scheduled at %v\n", domain, at))
+		for _, record := range records {
+			out.WriteString(fmt.Sprintf("%s last attempt at %v", record.Zone, record.Attempt))
+			if record.Failures > 0 {
+				out.WriteString(fmt.Sprintf(" failed %d time(s) with error %s", record.Failures, record.Error))
+			} else {
+				out.WriteString(" succeeded")
+			}
+			if at, ok := scheduled[record.Zone]; ok {
+				out.WriteString(fmt.Sprintf(", renewal scheduled at %v", at))
+				delete(scheduled, record.Zone)
+			}
+			out.WriteByte('\n')
+		}
+
+		var rest []string
+		for domain := range scheduled {
+			rest = append(rest, domain)
+		}
+		sort.Strings(rest)
+		for _, domain := range rest {
+			out.WriteString(fmt.Sprintf("%s renewal scheduled at %v\n", domain, scheduled[domain]))
 		}
 		return out.String(), nil
 
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"crypto/x509"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRenewAt(t *testing.T) {
+
+	manager := &implCertificateManager{renewBefore: RenewBefore}
+	notBefore := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 90 days certificate renews at 2/3 of the lifetime
+	leaf := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(90 * day)}
+	require.Equal(t, notBefore.Add(60*day), manager.renewAt(leaf))
+
+	// short living certificate renews not later than RenewBefore
+	leaf = &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(36 * time.Hour)}
+	require.Equal(t, leaf.NotAfter.Add(-RenewBefore), manager.renewAt(leaf))
+
+	manager.RenewJitter = time.Hour
+	for i := 0; i < 100; i++ {
+		at := manager.renewAt(leaf)
+		require.False(t, at.After(leaf.NotAfter.Add(-RenewBefore)))
+		require.True(t, at.After(leaf.NotAfter.Add(-RenewBefore-time.Hour)))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+
+	manager := &implCertificateManager{RetryMin: time.Minute, RetryMax: time.Hour}
+
+	within := func(expected time.Duration, actual time.Duration) {
+		require.True(t, actual >= expected-expected/10, "%v < %v", actual, expected)
+		require.True(t, actual <= expected+expected/10, "%v > %v", actual, expected)
+	}
+
+	for i := 0; i < 100; i++ {
+		within(time.Minute, manager.backoff(0))
+		within(time.Minute, manager.backoff(1))
+		within(2*time.Minute, manager.backoff(2))
+		within(32*time.Minute, manager.backoff(6))
+		within(time.Hour, manager.backoff(7))
+		within(time.Hour, manager.backoff(100))
+	}
+
+	manager.RetryMin = 0
+	require.Equal(t, time.Duration(0), manager.backoff(3))
+}
@@ -0,0 +1,423 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintpb"
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrEmptyCertificate = errors.New("empty certificate")
+
+const day = 24 * time.Hour
+
+type implCertificateMonitor struct {
+	Application sprint.Application `inject`
+	Properties  glue.Properties    `inject`
+	Log         *zap.Logger        `inject`
+
+	CertificateRepository sprint.CertificateRepository `inject`
+	CertificateManager    sprint.CertificateManager    `inject`
+	MailService           sprint.MailService           `inject`
+	JobService            sprint.JobService            `inject`
+
+	Interval        time.Duration  `value:"certificate.monitor.interval,default=1h"`
+	Delay           time.Duration  `value:"certificate.monitor.delay,default=1m"`
+	Thresholds      []int          `value:"certificate.monitor.thresholds,default=30;14;7;1"`
+
+	MailSender      string         `value:"certificate.monitor.mail.sender,default="`
+	MailRecipients  string         `value:"certificate.monitor.mail.recipients,default="`
+	MailTemplate    string         `value:"certificate.monitor.mail.template,default=templates/cert_expiring.tmpl"`
+
+	WebhookUrl      string         `value:"certificate.monitor.webhook.url,default="`
+	NotifyTimeout   time.Duration  `value:"certificate.monitor.notify.timeout,default=10s"`
+
+	httpClient  *http.Client
+
+	notified  sync.Map   // key is zone, value is *notifiedState
+
+	job       *periodicJob
+
+	lastCheck      atomic.Int64
+	lastErr        atomic.String
+	zones          atomic.Int32
+	expiring       atomic.Int32
+	expired        atomic.Int32
+	notifications  atomic.Int64
+	notifyErrors   atomic.Int64
+}
+
+type notifiedState struct {
+	notAfter   time.Time
+	threshold  int
+}
+
+func CertificateMonitor() api.CertificateMonitor {
+	return &implCertificateMonitor{}
+}
+
+func (t *implCertificateMonitor) BeanName() string {
+	return "certificate_monitor"
+}
+
+func (t *implCertificateMonitor) PostConstruct() error {
+
+	// descending order, first threshold is the earliest warning
+	sort.Sort(sort.Reverse(sort.IntSlice(t.Thresholds)))
+
+	t.httpClient = &http.Client{
+		Timeout: t.NotifyTimeout,
+	}
+
+	t.job = &periodicJob{
+		name:     t.BeanName(),
+		delay:    t.Delay,
+		interval: t.Interval,
+		execute: func(ctx context.Context) error {
+			return t.Check()
+		},
+		run: t.run,
+	}
+	return t.job.start(t.JobService)
+}
+
+func (t *implCertificateMonitor) Destroy() error {
+	t.job.stop()
+	return nil
+}
+
+func (t *implCertificateMonitor) run() {
+	if err := t.Check(); err != nil {
+		t.Log.Error("CertificateMonitorCheck", zap.Error(err))
+	}
+}
+
+func (t *implCertificateMonitor) GetStats(cb func(name, value string) bool) error {
+	if lastCheck := t.lastCheck.Load(); lastCheck != 0 {
+		cb("lastCheck", time.Unix(lastCheck, 0).String())
+	}
+	if lastErr := t.lastErr.Load(); lastErr != "" {
+		cb("lastErr", lastErr)
+	}
+	cb("zones", strconv.Itoa(int(t.zones.Load())))
+	cb("expiring", strconv.Itoa(int(t.expiring.Load())))
+	cb("expired", strconv.Itoa(int(t.expired.Load())))
+	cb("notifications", strconv.FormatInt(t.notifications.Load(), 10))
+	cb("notifyErrors", strconv.FormatInt(t.notifyErrors.Load(), 10))
+	return nil
+}
+
+func (t *implCertificateMonitor) maxThreshold() int {
+	if len(t.Thresholds) > 0 {
+		return t.Thresholds[0]
+	}
+	return 0
+}
+
+func (t *implCertificateMonitor) Check() (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+		if err != nil {
+			t.lastErr.Store(err.Error())
+		} else {
+			t.lastErr.Store("")
+		}
+	}()
+
+	now := time.Now()
+	renewErrors := t.CertificateManager.ListActive()
+
+	var zones, expiring, expired int32
+	err = t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
+
+		if entry.Certificates == nil {
+			return true
+		}
+
+		leaf, err := parseLeafCertificate(entry.Certificates.Certificate)
+		if err != nil {
+			t.Log.Error("CertificateMonitorParse", zap.String("zone", entry.Zone), zap.Error(err))
+			return true
+		}
+		zones++
+
+		left := leaf.NotAfter.Sub(now)
+		if left < 0 {
+			expired++
+		} else if left <= time.Duration(t.maxThreshold()) * day {
+			expiring++
+		}
+
+		threshold, ok := t.crossedThreshold(left)
+		if !ok {
+			t.notified.Delete(entry.Zone)
+			return true
+		}
+
+		if value, ok := t.notified.Load(entry.Zone); ok {
+			if s, ok := value.(*notifiedState); ok && s.notAfter.Equal(leaf.NotAfter) && s.threshold <= threshold {
+				// already notified
+				return true
+			}
+		}
+
+		event := t.newEvent(entry, leaf, now)
+		event.Threshold = threshold
+		if left < 0 {
+			event.Reason = "expired"
+		} else {
+			event.Reason = fmt.Sprintf("%d days threshold", threshold)
+		}
+		if renewErr := renewErrors[entry.Zone]; renewErr != nil {
+			event.Error = renewErr.Error()
+		}
+
+		if err := t.Notify(event); err != nil {
+			t.Log.Error("CertificateMonitorNotify", zap.String("zone", entry.Zone), zap.Error(err))
+		}
+
+		// do not repeat failed notifications on every check
+		t.notified.Store(entry.Zone, &notifiedState{notAfter: leaf.NotAfter, threshold: threshold})
+		return true
+	})
+
+	t.zones.Store(zones)
+	t.expiring.Store(expiring)
+	t.expired.Store(expired)
+	t.lastCheck.Store(now.Unix())
+	return err
+}
+
+/**
+Returns the smallest threshold in days that is already crossed, zero for expired certificates.
+*/
+
+func (t *implCertificateMonitor) crossedThreshold(left time.Duration) (int, bool) {
+	if left < 0 {
+		return 0, true
+	}
+	threshold, found := 0, false
+	for _, days := range t.Thresholds {
+		if left <= time.Duration(days) * day {
+			threshold, found = days, true
+		}
+	}
+	return threshold, found
+}
+
+func (t *implCertificateMonitor) newEvent(entry *sprintpb.Zone, leaf *x509.Certificate, now time.Time) *api.CertificateEvent {
+	return &api.CertificateEvent{
+		Zone:     entry.Zone,
+		Domains:  entry.Domains,
+		NotAfter: leaf.NotAfter,
+		DaysLeft: int(leaf.NotAfter.Sub(now) / day),
+	}
+}
+
+func (t *implCertificateMonitor) ListExpiring(within time.Duration) ([]*api.CertificateEvent, error) {
+
+	now := time.Now()
+	renewErrors := t.CertificateManager.ListActive()
+
+	var list []*api.CertificateEvent
+	err := t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
+
+		if entry.Certificates == nil {
+			return true
+		}
+
+		leaf, err := parseLeafCertificate(entry.Certificates.Certificate)
+		if err != nil {
+			return true
+		}
+
+		if leaf.NotAfter.Sub(now) <= within {
+			event := t.newEvent(entry, leaf, now)
+			if renewErr := renewErrors[entry.Zone]; renewErr != nil {
+				event.Error = renewErr.Error()
+			}
+			list = append(list, event)
+		}
+		return true
+	})
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].NotAfter.Before(list[j].NotAfter)
+	})
+
+	return list, err
+}
+
+func (t *implCertificateMonitor) Notify(event *api.CertificateEvent) error {
+
+	t.Log.Warn("CertificateExpiring",
+		zap.String("zone", event.Zone),
+		zap.String("serial", event.Serial),
+		zap.Strings("domains", event.Domains),
+		zap.Time("notAfter", event.NotAfter),
+		zap.Int("daysLeft", event.DaysLeft),
+		zap.String("reason", event.Reason),
+		zap.String("error", event.Error))
+
+	t.notifications.Inc()
+
+	var errs []string
+
+	if t.MailSender != "" && t.MailRecipients != "" {
+		mail := &sprint.Mail{
+			Sender:       t.MailSender,
+			Recipients:   strings.Split(t.MailRecipients, ","),
+			Subject:      t.subject(event),
+			TextTemplate: t.MailTemplate,
+			Data:         event,
+		}
+		if err := t.MailService.SendMail(mail, t.NotifyTimeout, false); err != nil {
+			errs = append(errs, fmt.Sprintf("mail: %v", err))
+		}
+	}
+
+	if t.WebhookUrl != "" {
+		if err := t.sendWebhook(event); err != nil {
+			errs = append(errs, fmt.Sprintf("webhook: %v", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		t.notifyErrors.Inc()
+		return errors.Errorf("notification failed, %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (t *implCertificateMonitor) subject(event *api.CertificateEvent) string {
+	if event.Serial != "" {
+		return fmt.Sprintf("[%s] Unexpected certificate for %s in CT log", t.Application.Name(), event.Zone)
+	}
+	return fmt.Sprintf("[%s] Certificate for %s expires in %d day(s)", t.Application.Name(), event.Zone, event.DaysLeft)
+}
+
+type certificateWebhookEvent struct {
+	Application  string    `json:"application"`
+	Zone         string    `json:"zone"`
+	Domains      []string  `json:"domains"`
+	NotAfter     time.Time `json:"notAfter"`
+	DaysLeft     int       `json:"daysLeft"`
+	Threshold    int       `json:"threshold"`
+	Reason       string    `json:"reason"`
+	Error        string    `json:"error,omitempty"`
+	Serial       string    `json:"serial,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+}
+
+func (t *implCertificateMonitor) sendWebhook(event *api.CertificateEvent) error {
+
+	body, err := json.Marshal(&certificateWebhookEvent{
+		Application: t.Application.Name(),
+		Zone:        event.Zone,
+		Domains:     event.Domains,
+		NotAfter:    event.NotAfter,
+		DaysLeft:    event.DaysLeft,
+		Threshold:   event.Threshold,
+		Reason:      event.Reason,
+		Error:       event.Error,
+		Serial:      event.Serial,
+		Issuer:      event.Issuer,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.WebhookUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode / 100 != 2 {
+		return errors.Errorf("unexpected status code %d from '%s'", resp.StatusCode, t.WebhookUrl)
+	}
+
+	return nil
+}
+
+func (t *implCertificateMonitor) ExecuteCommand(cmd string, args []string) (string, error) {
+
+	if cmd != "expiring" {
+		return "", errors.Errorf("unknown command '%s'", cmd)
+	}
+
+	days := t.maxThreshold()
+	if len(args) > 0 {
+		var err error
+		days, err = strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Sprintf("Usage: ./%s cert expiring [days]", t.Application.Name()), nil
+		}
+	}
+
+	list, err := t.ListExpiring(time.Duration(days) * day)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	out.WriteString("Zone,Domains,NotAfter,DaysLeft,Error\n")
+	for _, event := range list {
+		out.WriteString(fmt.Sprintf("%s,%+v,%s,%d,%s\n", event.Zone, event.Domains, event.NotAfter.Format(time.RFC3339), event.DaysLeft, event.Error))
+	}
+	return out.String(), nil
+}
+
+func parseLeafCertificate(certPem []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPem)
+	if block == nil {
+		return nil, ErrEmptyCertificate
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type stubZoneRepository struct {
+	sprint.CertificateRepository
+	backend store.DataStore
+	zones   []*sprintpb.Zone
+}
+
+func (t *stubZoneRepository) Backend() store.DataStore {
+	return t.backend
+}
+
+func (t *stubZoneRepository) ListZones(prefix string, cb func(*sprintpb.Zone) bool) error {
+	for _, zone := range t.zones {
+		if !cb(zone) {
+			break
+		}
+	}
+	return nil
+}
+
+func (t *stubZoneRepository) FindZone(zone string) (*sprintpb.Zone, error) {
+	for _, entry := range t.zones {
+		if entry.Zone == zone {
+			return entry, nil
+		}
+	}
+	return new(sprintpb.Zone), nil
+}
+
+type stubActiveManager struct {
+	sprint.CertificateManager
+}
+
+func (t *stubActiveManager) ListActive() map[string]error {
+	return map[string]error{}
+}
+
+func testCertificatePEM(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    notAfter.Add(-90 * day),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCrossedThreshold(t *testing.T) {
+
+	monitor := &implCertificateMonitor{Thresholds: []int{30, 14, 7, 1}}
+
+	cases := []struct {
+		left      time.Duration
+		threshold int
+		crossed   bool
+	}{
+		{60 * day, 0, false},
+		{30*day + time.Minute, 0, false},
+		{30 * day, 30, true},
+		{20 * day, 30, true},
+		{14 * day, 14, true},
+		{3 * day, 7, true},
+		{time.Hour, 1, true},
+		{-time.Hour, 0, true},
+	}
+
+	for _, c := range cases {
+		threshold, crossed := monitor.crossedThreshold(c.left)
+		require.Equal(t, c.crossed, crossed, c.left.String())
+		require.Equal(t, c.threshold, threshold, c.left.String())
+	}
+
+	empty := &implCertificateMonitor{}
+	_, crossed := empty.crossedThreshold(time.Hour)
+	require.False(t, crossed)
+}
+
+func TestCertificateMonitorDedupe(t *testing.T) {
+
+	zone := &sprintpb.Zone{Zone: "example.com", Domains: []string{"example.com"}}
+	repository := &stubZoneRepository{zones: []*sprintpb.Zone{zone}}
+
+	monitor := &implCertificateMonitor{
+		Log:                   zap.NewNop(),
+		CertificateRepository: repository,
+		CertificateManager:    &stubActiveManager{},
+		Thresholds:            []int{30, 14, 7, 1},
+	}
+
+	setNotAfter := func(notAfter time.Time) {
+		zone.Certificates = &sprintpb.Certificates{Certificate: testCertificatePEM(t, notAfter)}
+	}
+
+	now := time.Now()
+
+	setNotAfter(now.Add(60 * day))
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(0), monitor.notifications.Load())
+
+	first := now.Add(20 * day)
+	setNotAfter(first)
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(1), monitor.notifications.Load())
+
+	// same certificate within the same threshold
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(1), monitor.notifications.Load())
+
+	// next threshold crossed
+	setNotAfter(first.Add(-10 * day))
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(2), monitor.notifications.Load())
+
+	// renewed certificate clears the state
+	setNotAfter(now.Add(90 * day))
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(2), monitor.notifications.Load())
+	_, ok := monitor.notified.Load(zone.Zone)
+	require.False(t, ok)
+
+	setNotAfter(now.Add(20 * day))
+	require.NoError(t, monitor.Check())
+	require.Equal(t, int64(3), monitor.notifications.Load())
+	require.Equal(t, int32(1), monitor.expiring.Load())
+}
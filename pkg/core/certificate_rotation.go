@@ -0,0 +1,299 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintpb"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const rotationRecordPrefix = "rotation"
+
+type crossSigner interface {
+	CrossSign(cert *x509.Certificate) ([]byte, error)
+}
+
+// rotationRecord keeps the state of the self signer rotation until the previous signer is retired,
+// RetireAt is the end of the planned overlap, the retirement itself is always explicit.
+type rotationRecord struct {
+	Name             string    `json:"name"`
+	Previous         string    `json:"previous"`
+	Intermediate     bool      `json:"intermediate,omitempty"`
+	CrossCertificate []byte    `json:"crossCertificate,omitempty"`
+	RotatedAt        time.Time `json:"rotatedAt"`
+	RetireAt         time.Time `json:"retireAt"`
+}
+
+func (t *implCertificateService) selfRotate(args []string) (string, error) {
+
+	args, inter := removeFlag(args, "--inter")
+
+	usage := fmt.Sprintf("Usage: ./%s cert self rotate name [--inter] [--overlap days]", t.Application.Name())
+	if len(args) < 1 {
+		return usage, nil
+	}
+
+	name := strings.ToLower(args[0])
+	overlap := t.SelfOverlap
+
+	if len(args) > 1 {
+		if len(args) != 3 || args[1] != "--overlap" {
+			return usage, nil
+		}
+		days, err := strconv.Atoi(args[2])
+		if err != nil || days < 0 {
+			return "", errors.Errorf("invalid overlap days '%s'", args[2])
+		}
+		overlap = time.Duration(days) * day
+	}
+
+	record, zones, err := t.RotateSelfSigner(name, inter, overlap)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Rotated Self Signer '%s', re-issued zones %+v, previous signer '%s' is trusted until retired, run 'cert self retire %s' after %s",
+		name, zones, record.Previous, name, record.RetireAt.Format(time.RFC3339)), nil
+}
+
+func (t *implCertificateService) selfRetire(args []string) (string, error) {
+
+	if len(args) < 1 {
+		return fmt.Sprintf("Usage: ./%s cert self retire name", t.Application.Name()), nil
+	}
+
+	name := strings.ToLower(args[0])
+
+	zones, err := t.RetireSelfSigner(name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Retired previous Self Signer of '%s', re-issued zones %+v", name, zones), nil
+}
+
+// RotateSelfSigner replaces the root, or only the intermediate, of the self signer and re-issues
+// its zones. The previous signer is kept for the overlap period to trust the client certificates
+// issued by it, and the new root is cross-signed by the previous root for the clients trusting it.
+func (t *implCertificateService) RotateSelfSigner(name string, inter bool, overlap time.Duration) (*rotationRecord, []string, error) {
+
+	record, err := t.loadRotationRecord(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if record != nil {
+		return nil, nil, errors.Errorf("self signer '%s' is in rotation overlap until %s, retire it first", name, record.RetireAt.Format(time.RFC3339))
+	}
+
+	prev, err := t.CertificateRepository.FindSelfSigner(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if prev.Name == "" {
+		return nil, nil, errors.Errorf("self signer '%s' not found", name)
+	}
+
+	prevIssuer, err := t.CertificateIssueService.LoadIssuer(prev)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "load self issuer '%s'", name)
+	}
+
+	prevRoot := prevIssuer
+	for p, ok := prevRoot.Parent(); ok; p, ok = p.Parent() {
+		prevRoot = p
+	}
+
+	record = &rotationRecord{
+		Name:         name,
+		Previous:     name + api.PreviousSignerSuffix,
+		Intermediate: inter,
+		RotatedAt:    time.Now(),
+	}
+	record.RetireAt = record.RotatedAt.Add(overlap)
+
+	var issuer sprint.CertificateIssuer
+	if inter {
+
+		if prev.Issuer == nil {
+			return nil, nil, errors.Errorf("self signer '%s' does not have intermediate", name)
+		}
+
+		issuer, err = prevRoot.IssueInterCert(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	} else {
+
+		info, err := t.CertificateIssueService.LoadCertificateDesc()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		root, err := t.CertificateIssueService.CreateIssuer(name, info)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		signer, ok := prevRoot.(crossSigner)
+		if !ok {
+			return nil, nil, errors.Errorf("self issuer '%s' does not support cross-signing", name)
+		}
+
+		record.CrossCertificate, err = signer.CrossSign(root.Certificate().Certificate())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "cross-sign new root of '%s'", name)
+		}
+
+		issuer = root
+		if prev.Issuer != nil {
+			issuer, err = root.IssueInterCert(name)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	previous := proto.Clone(prev).(*sprintpb.SelfSigner)
+	previous.Name = record.Previous
+
+	if err := t.CertificateRepository.SaveSelfSigner(previous); err != nil {
+		return nil, nil, errors.Wrapf(err, "save previous self signer '%s'", previous.Name)
+	}
+
+	if err := t.saveRotationRecord(record); err != nil {
+		return nil, nil, err
+	}
+
+	if err := t.CertificateRepository.SaveSelfSigner(newSelfSigner(name, issuer)); err != nil {
+		return nil, nil, err
+	}
+
+	t.Log.Info("SelfSignerRotate",
+		zap.String("name", name),
+		zap.Bool("intermediate", inter),
+		zap.String("previousSubject", prevIssuer.Certificate().Certificate().Subject.CommonName),
+		zap.String("subject", issuer.Certificate().Certificate().Subject.CommonName),
+		zap.Time("retireAt", record.RetireAt))
+
+	zones, err := t.reissueSelfZones(name)
+	return record, zones, err
+}
+
+// RetireSelfSigner removes the previous self signer and the cross certificate, this is the only
+// place that ends the rotation, the running TLS configs stop trusting the previous root on the next handshake.
+func (t *implCertificateService) RetireSelfSigner(name string) ([]string, error) {
+
+	record, err := t.loadRotationRecord(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, errors.Errorf("self signer '%s' is not in rotation", name)
+	}
+
+	if err := t.CertificateRepository.DeleteSelfSigner(record.Previous); err != nil {
+		return nil, err
+	}
+
+	if err := t.removeRotationRecord(name); err != nil {
+		return nil, err
+	}
+
+	t.Log.Info("SelfSignerRetire", zap.String("name", name), zap.String("previous", record.Previous), zap.Time("rotatedAt", record.RotatedAt))
+
+	return t.reissueSelfZones(name)
+}
+
+// crossCertificate returns the cross certificate to append to the chain of the zones issued by
+// the self signer until the previous signer is retired.
+func (t *implCertificateService) crossCertificate(name string) ([]byte, error) {
+
+	record, err := t.loadRotationRecord(name)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	return record.CrossCertificate, nil
+}
+
+func (t *implCertificateService) reissueSelfZones(name string) ([]string, error) {
+
+	var list []*sprintpb.Zone
+	err := t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
+		signer := entry.SelfSigner
+		if signer == "" {
+			signer = "localhost"
+		}
+		if entry.CertProvider == "self" && signer == name {
+			list = append(list, entry)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []string
+	for _, entry := range list {
+		if err := t.IssueSelfSignedCertificate(entry); err != nil {
+			return zones, errors.Wrapf(err, "re-issue zone '%s'", entry.Zone)
+		}
+		if err := t.CertificateRepository.SaveZone(entry); err != nil {
+			return zones, err
+		}
+		zones = append(zones, entry.Zone)
+	}
+
+	return zones, nil
+}
+
+func (t *implCertificateService) loadRotationRecord(name string) (*rotationRecord, error) {
+	content, err := t.CertificateRepository.Backend().Get(context.Background()).ByKey("%s:%s:%s", CertBucket, rotationRecordPrefix, name).ToBinary()
+	if err != nil || len(content) == 0 {
+		return nil, err
+	}
+	record := new(rotationRecord)
+	if err := json.Unmarshal(content, record); err != nil {
+		return nil, errors.Wrapf(err, "parse rotation record of '%s'", name)
+	}
+	return record, nil
+}
+
+func (t *implCertificateService) saveRotationRecord(record *rotationRecord) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return t.CertificateRepository.Backend().Set(context.Background()).ByKey("%s:%s:%s", CertBucket, rotationRecordPrefix, record.Name).Binary(content)
+}
+
+func (t *implCertificateService) removeRotationRecord(name string) error {
+	return t.CertificateRepository.Backend().Remove(context.Background()).ByKey("%s:%s:%s", CertBucket, rotationRecordPrefix, name).Do()
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"bytes"
+	"crypto/x509"
+	"github.com/codeallergy/cachestore"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"testing"
+	"time"
+)
+
+type stubSelfSignerRepository struct {
+	sprint.CertificateRepository
+	backend store.DataStore
+	signers map[string]*sprintpb.SelfSigner
+	zones   map[string]*sprintpb.Zone
+}
+
+func (t *stubSelfSignerRepository) SaveSelfSigner(self *sprintpb.SelfSigner) error {
+	t.signers[self.Name] = proto.Clone(self).(*sprintpb.SelfSigner)
+	return nil
+}
+
+func (t *stubSelfSignerRepository) FindSelfSigner(name string) (*sprintpb.SelfSigner, error) {
+	if self, ok := t.signers[name]; ok {
+		return proto.Clone(self).(*sprintpb.SelfSigner), nil
+	}
+	return new(sprintpb.SelfSigner), nil
+}
+
+func (t *stubSelfSignerRepository) DeleteSelfSigner(name string) error {
+	delete(t.signers, name)
+	return nil
+}
+
+func (t *stubSelfSignerRepository) SaveZone(zone *sprintpb.Zone) error {
+	t.zones[zone.Zone] = proto.Clone(zone).(*sprintpb.Zone)
+	return nil
+}
+
+func (t *stubSelfSignerRepository) ListZones(prefix string, cb func(*sprintpb.Zone) bool) error {
+	var names []string
+	for name := range t.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !cb(proto.Clone(t.zones[name]).(*sprintpb.Zone)) {
+			break
+		}
+	}
+	return nil
+}
+
+func (t *stubSelfSignerRepository) Backend() store.DataStore {
+	return t.backend
+}
+
+func newRotationTest(t *testing.T, withInter bool) (*implCertificateService, *stubSelfSignerRepository) {
+
+	repository := &stubSelfSignerRepository{
+		backend: cachestore.New("test-storage"),
+		signers: make(map[string]*sprintpb.SelfSigner),
+		zones:   make(map[string]*sprintpb.Zone),
+	}
+
+	service := &implCertificateService{
+		Application:             &stubApplication{},
+		Log:                     zap.NewNop(),
+		CertificateRepository:   repository,
+		CertificateIssueService: &implCertificateIssuerService{Log: zap.NewNop(), RsaLen: 2048},
+	}
+
+	require.NoError(t, service.CreateSelfSigner("test", withInter))
+
+	zone := &sprintpb.Zone{Zone: "example.com", Domains: []string{"example.com"}, CertProvider: "self", SelfSigner: "test"}
+	require.NoError(t, service.IssueSelfSignedCertificate(zone))
+	require.NoError(t, repository.SaveZone(zone))
+
+	return service, repository
+}
+
+func rootOf(t *testing.T, self *sprintpb.SelfSigner) *x509.Certificate {
+	for self.Issuer != nil {
+		self = self.Issuer
+	}
+	cert, err := parseLeafCertificate(self.Certificate)
+	require.NoError(t, err)
+	return cert
+}
+
+/**
+Verifies the zone certificate by the chain stored in the zone against the single root.
+*/
+
+func verifyZone(t *testing.T, zone *sprintpb.Zone, root *x509.Certificate) error {
+
+	leaf, err := parseLeafCertificate(zone.Certificates.Certificate)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(zone.Certificates.IssuerCertificate)
+
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, DNSName: "example.com"})
+	return err
+}
+
+func TestRotateSelfSigner(t *testing.T) {
+
+	service, repository := newRotationTest(t, false)
+	oldRoot := rootOf(t, repository.signers["test"])
+
+	record, zones, err := service.RotateSelfSigner("test", false, day)
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, zones)
+	require.Equal(t, "test"+api.PreviousSignerSuffix, record.Previous)
+	require.NotEmpty(t, record.CrossCertificate)
+
+	newRoot := rootOf(t, repository.signers["test"])
+	require.False(t, oldRoot.Equal(newRoot))
+	require.True(t, oldRoot.Equal(rootOf(t, repository.signers[record.Previous])))
+
+	// re-issued zone is trusted by both roots through the cross certificate
+	zone := repository.zones["example.com"]
+	require.True(t, bytes.Contains(zone.Certificates.IssuerCertificate, record.CrossCertificate))
+	require.NoError(t, verifyZone(t, zone, newRoot))
+	require.NoError(t, verifyZone(t, zone, oldRoot))
+
+	_, _, err = service.RotateSelfSigner("test", false, day)
+	require.Error(t, err)
+}
+
+func TestRotateSelfSignerIntermediate(t *testing.T) {
+
+	service, repository := newRotationTest(t, true)
+	oldRoot := rootOf(t, repository.signers["test"])
+	oldInter := repository.signers["test"].Certificate
+
+	record, zones, err := service.RotateSelfSigner("test", true, day)
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, zones)
+	require.Empty(t, record.CrossCertificate)
+
+	require.True(t, oldRoot.Equal(rootOf(t, repository.signers["test"])))
+	require.NotEqual(t, oldInter, repository.signers["test"].Certificate)
+	require.NoError(t, verifyZone(t, repository.zones["example.com"], oldRoot))
+}
+
+func TestRetireSelfSigner(t *testing.T) {
+
+	service, repository := newRotationTest(t, false)
+	oldRoot := rootOf(t, repository.signers["test"])
+
+	record, _, err := service.RotateSelfSigner("test", false, day)
+	require.NoError(t, err)
+
+	// overlap is over, but nothing is retired until it is requested
+	record.RetireAt = time.Now().Add(-time.Hour)
+	require.NoError(t, service.saveRotationRecord(record))
+
+	zone := &sprintpb.Zone{Zone: "example.com", Domains: []string{"example.com"}, CertProvider: "self", SelfSigner: "test"}
+	require.NoError(t, service.IssueSelfSignedCertificate(zone))
+	require.True(t, bytes.Contains(zone.Certificates.IssuerCertificate, record.CrossCertificate))
+	require.Contains(t, repository.signers, record.Previous)
+
+	zones, err := service.RetireSelfSigner("test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, zones)
+	require.NotContains(t, repository.signers, record.Previous)
+
+	loaded, err := service.loadRotationRecord("test")
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	zone = repository.zones["example.com"]
+	require.False(t, bytes.Contains(zone.Certificates.IssuerCertificate, record.CrossCertificate))
+	require.NoError(t, verifyZone(t, zone, rootOf(t, repository.signers["test"])))
+	require.Error(t, verifyZone(t, zone, oldRoot))
+
+	_, err = service.RetireSelfSigner("test")
+	require.Error(t, err)
+}
@@ -16,6 +16,7 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/tls"
 	"crypto/x509"
@@ -32,7 +33,11 @@ import (
 	"github.com/codeallergy/sprintpb"
 	"github.com/codeallergy/sealmod"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
 	"github.com/codeallergy/sprintframework/pkg/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/net/idna"
 	legolog "github.com/go-acme/lego/v4/log"
@@ -41,6 +46,7 @@ import (
 	"log"
 	"net"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -60,14 +66,18 @@ type implCertificateService struct {
 	SealService             seal.SealService             `inject`
 	CertificateIssueService sprint.CertificateIssueService `inject`
 	WhoisService            sprint.WhoisService            `inject`
+	CertificateAuthority    api.CertificateAuthority       `inject`
 
 	Algorithm     string   `value="tls.certificate.algorithm,default=RSA2048"`
 
 	DNSProviders  map[string]sprint.DNSProvider `inject:"optional"`
+	DNSProviderFactories []api.DNSProviderFactory `inject:"optional"`
 	providerMap   map[string]sprint.DNSProvider // key is the provider name, not bean_name
 	providerList  []string
 
 	CompanyName   string        `value:"application.company,default=sprint"`
+	SelfOverlap   time.Duration `value:"certificate.self.overlap,default=720h"`
+	AcmeDirectory string        `value:"acme.directory,default="`
 
 	acmeMutex  sync.Mutex
 
@@ -95,14 +105,7 @@ func (t *implCertificateService) PostConstruct() (err error) {
 		}
 	}()
 
-	for beanName, prov := range t.DNSProviders {
-		name := beanName
-		if strings.HasSuffix(name, "_provider") {
-			name = name[:len(name) - len("_provider")]
-		}
-		t.providerMap[name] = prov
-		t.providerList = append(t.providerList, name)
-	}
+	t.providerList = indexDNSProviders(t.DNSProviders, t.DNSProviderFactories, t.providerMap)
 
 	entry, err := t.CertificateRepository.FindSelfSigner("localhost")
 	if err != nil {
@@ -221,6 +224,11 @@ func (t *implCertificateService) CreateSelfSigner(cn string, withInter bool) err
 		}
 	}
 
+	return t.CertificateRepository.SaveSelfSigner(newSelfSigner(cn, issuer))
+}
+
+func newSelfSigner(name string, issuer sprint.CertificateIssuer) *sprintpb.SelfSigner {
+
 	entry := new(sprintpb.SelfSigner)
 
 	for i, ok, e := issuer, true, entry; ok; i, ok = i.Parent() {
@@ -231,11 +239,15 @@ func (t *implCertificateService) CreateSelfSigner(cn string, withInter bool) err
 		e = e.Issuer
 	}
 
-	entry.Issuer.Name = cn
-	return t.CertificateRepository.SaveSelfSigner(entry.Issuer)
+	entry.Issuer.Name = name
+	return entry.Issuer
 }
 
 func (t *implCertificateService) RenewCertificate(zone string) error {
+	return t.RenewZone(context.Background(), zone)
+}
+
+func (t *implCertificateService) RenewZone(ctx context.Context, zone string) error {
 
 	entry, err := t.CertificateRepository.FindZone(zone)
 	if err != nil {
@@ -254,7 +266,7 @@ func (t *implCertificateService) RenewCertificate(zone string) error {
 		}
 		return t.CertificateRepository.SaveZone(entry)
 	case "acme":
-		_, err = t.IssueAcmeCertificate(entry)
+		_, err = t.issueAcmeCertificate(ctx, entry)
 		if err != nil {
 			return err
 		}
@@ -303,6 +315,12 @@ func (t *implCertificateService) IssueSelfSignedCertificate(entry *sprintpb.Zone
 		buf.Write(i.Certificate().CertFileContents())
 	}
 
+	cross, err := t.crossCertificate(entry.SelfSigner)
+	if err != nil {
+		return err
+	}
+	buf.Write(cross)
+
 	entry.Certificates = &sprintpb.Certificates{
 		Domain:            entry.Zone,
 		CertUrl:           "",
@@ -317,6 +335,12 @@ func (t *implCertificateService) IssueSelfSignedCertificate(entry *sprintpb.Zone
 }
 
 func (t *implCertificateService) IssueAcmeCertificate(entry *sprintpb.Zone) (string, error) {
+	return t.issueAcmeCertificate(context.Background(), entry)
+}
+
+func (t *implCertificateService) issueAcmeCertificate(ctx context.Context, entry *sprintpb.Zone) (string, error) {
+
+	logger := t.Log.With(util.TraceContext(ctx))
 
 	if len(entry.Domains) == 0 {
 		return "", errors.Errorf("zone name '%s' has empty domains", entry.Zone)
@@ -339,15 +363,13 @@ func (t *implCertificateService) IssueAcmeCertificate(entry *sprintpb.Zone) (str
 		return "", err
 	}
 
-	config := lego.NewConfig(acmeUserAdapter{user})
+	config := t.newAcmeConfig(user)
 
 	config.Certificate = lego.CertificateConfig{
 		KeyType: getKeyType(t.Algorithm),
 		Timeout: 60 * time.Second,
 	}
 
-	config.UserAgent = fmt.Sprintf("%s/%s", t.Application.Name(), t.Application.Version())
-
 	client, err := lego.NewClient(config)
 	if err != nil {
 		return "", err
@@ -368,7 +390,7 @@ func (t *implCertificateService) IssueAcmeCertificate(entry *sprintpb.Zone) (str
 
 		renew := entry.Certificates
 
-		t.Log.Info("RenewRequest",
+		logger.Info("RenewRequest",
 			zap.String("commonName", entry.Zone),
 			zap.String("renew.Domain", renew.Domain),
 			zap.Strings("domains", entry.Domains),
@@ -383,31 +405,32 @@ func (t *implCertificateService) IssueAcmeCertificate(entry *sprintpb.Zone) (str
 			CSR:               renew.Csr,
 		}
 
-		logContent = t.doAcmeCall(func() {
+		logContent = t.doAcmeCall(ctx, "AcmeCertificateRenew", []attribute.KeyValue{attribute.String("acme.zone", entry.Zone)}, func() error {
 
 			reg, err := client.Registration.QueryRegistration()
 
 			if err != nil {
 				reg, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 				if err != nil {
-					return
+					return err
 				}
 			}
 
 			user.Registration = wrapAcmeResource(reg)
 			certificates, err = client.Certificate.Renew(certs, true, true, "")
+			return err
 		})
 
 		if err != nil {
-			t.Log.Warn("CertificateRenew", zap.String("zone", entry.Zone), zap.String("log", string(logContent)), zap.Error(err))
+			logger.Warn("CertificateRenew", zap.String("zone", entry.Zone), zap.String("log", string(logContent)), zap.Error(err))
 			return "", err
 		}else {
-			t.Log.Info("CertificateRenew", zap.String("zone", entry.Zone), zap.String("log", string(logContent)))
+			logger.Info("CertificateRenew", zap.String("zone", entry.Zone), zap.String("log", string(logContent)))
 		}
 
 	} else {
 
-		t.Log.Info("ObtainRequest",
+		logger.Info("ObtainRequest",
 			zap.String("commonName", entry.Zone),
 			zap.Strings("domains", entry.Domains),
 			zap.String("email", user.Email))
@@ -418,26 +441,27 @@ func (t *implCertificateService) IssueAcmeCertificate(entry *sprintpb.Zone) (str
 			MustStaple: true,
 		}
 
-		logContent = t.doAcmeCall(func() {
+		logContent = t.doAcmeCall(ctx, "AcmeCertificateObtain", []attribute.KeyValue{attribute.String("acme.zone", entry.Zone)}, func() error {
 
 			reg, err := client.Registration.QueryRegistration()
 
 			if err != nil {
 				reg, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 				if err != nil {
-					return
+					return err
 				}
 			}
 
 			user.Registration = wrapAcmeResource(reg)
 			certificates, err = client.Certificate.Obtain(request)
+			return err
 		})
 
 		if err != nil {
-			t.Log.Warn("CertificateObtain", zap.String("zone", entry.Zone), zap.String("log", string(logContent)), zap.Error(err))
+			logger.Warn("CertificateObtain", zap.String("zone", entry.Zone), zap.String("log", string(logContent)), zap.Error(err))
 			return "", err
 		} else {
-			t.Log.Info("CertificateObtain", zap.String("zone", entry.Zone), zap.String("log", string(logContent)))
+			logger.Info("CertificateObtain", zap.String("zone", entry.Zone), zap.String("log", string(logContent)))
 		}
 
 	}
@@ -497,7 +521,11 @@ func wrapAcmeResource(r *registration.Resource) *sprint.AcmeResource {
 	}
 }
 
-func (t *implCertificateService) doAcmeCall(cb func()) []byte {
+/**
+	Runs the ACME call in the span that joins the trace of the context, returns the lego log of the call
+*/
+
+func (t *implCertificateService) doAcmeCall(ctx context.Context, spanName string, attrs []attribute.KeyValue, cb func() error) []byte {
 
 	t.acmeMutex.Lock()
 	saveLogger := legolog.Logger
@@ -505,7 +533,8 @@ func (t *implCertificateService) doAcmeCall(cb func()) []byte {
 	var buf bytes.Buffer
 	legolog.Logger = log.New(&buf, "", log.LstdFlags)
 
-	cb()
+	_, span := util.StartSpan(ctx, spanName, trace.WithAttributes(attrs...))
+	util.EndSpan(span, cb())
 
 	legolog.Logger = saveLogger
 	t.acmeMutex.Unlock()
@@ -592,6 +621,33 @@ func (t *implCertificateService) dumpCert(args []string) (string, error) {
 	return protojson.Format(entry), nil
 }
 
+func (t *implCertificateService) ExportZone(zone, format, password string) ([]byte, string, error) {
+
+	entry, err := t.CertificateRepository.FindZone(zone)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if entry.Zone == "" {
+		return nil, "", errors.Errorf("zone '%s' not found", zone)
+	}
+
+	if entry.Certificates == nil || entry.Certificates.Certificate == nil {
+		return nil, "", errors.Errorf("zone '%s' has empty certificates", zone)
+	}
+
+	bundle, err := util.ParseCertificateBundle(entry.Zone, entry.Certificates.PrivateKey, entry.Certificates.Certificate, entry.Certificates.IssuerCertificate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "" {
+		format = "pem"
+	}
+
+	return util.ExportCertificateBundle(bundle, strings.ToLower(format), password)
+}
+
 func (t *implCertificateService) uploadCert(args []string) (string, error) {
 	if len(args) < 1 {
 		return fmt.Sprintf("Usage: ./%s cert upload dump_file.json", t.Application.Name()), nil
@@ -652,45 +708,128 @@ func (t *implCertificateService) createCert(args []string) (string, error) {
 
 func (t *implCertificateService) createSelfCert(args []string) (string, error) {
 
+	args, exact := removeFlag(args, "--exact")
+
 	if len(args) < 1 {
-		return fmt.Sprintf("Usage: ./%s cert create self domain [self-signer]", t.Application.Name()), nil
+		return fmt.Sprintf("Usage: ./%s cert create self domain[,domain...] [self-signer] [--exact]", t.Application.Name()), nil
 	}
 
-	domain := util.UnFqdn(args[0])
-	args = args[1:]
+	req := &pb.CreateZoneRequest{
+		CertProvider: "self",
+		Domains:      strings.Split(args[0], ","),
+		Exact:        exact,
+	}
 
-	punycode, err := idna.Lookup.ToASCII(domain)
+	if len(args) > 1 {
+		req.SelfSigner = args[1]
+	}
+
+	_, msg, err := t.CreateZone(context.Background(), req)
+	return msg, err
+}
+
+func (t *implCertificateService) createAcmeCert(args []string) (string, error) {
+
+	args, exact := removeFlag(args, "--exact")
+
+	if len(args) < 2 {
+		return fmt.Sprintf("Usage: ./%s cert create acme domain[,domain...] email [dns_provider] [--exact]", t.Application.Name()), nil
+	}
+
+	req := &pb.CreateZoneRequest{
+		CertProvider: "acme",
+		Domains:      strings.Split(args[0], ","),
+		Exact:        exact,
+		AcmeEmail:    args[1],
+	}
+
+	if len(args) > 2 {
+		req.DnsProvider = args[2]
+	}
+
+	_, msg, err := t.CreateZone(context.Background(), req)
+	return msg, err
+}
+
+func (t *implCertificateService) createCustomCert(args []string) (string, error) {
+
+	if len(args) < 4 {
+		return fmt.Sprintf("Usage: ./%s cert create custom domain cert_file key_file issuer_cert_file", t.Application.Name()), nil
+	}
+
+	certFile := args[1]
+	keyFile := args[2]
+	issuerCertFile := args[3]
+
+	certContents, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "read file '%s'", certFile)
+	}
+
+	keyContents, err := ioutil.ReadFile(keyFile)
 	if err != nil {
-		return"", errors.Wrapf(err, "domain name '%s' contains invalid character", domain)
+		return "", errors.Wrapf(err, "read file '%s'", keyFile)
 	}
 
-	zone, err := util.ToZone(punycode)
+	issuerCertContents, err := ioutil.ReadFile(issuerCertFile)
 	if err != nil {
-		return "", err
+		return "", errors.Wrapf(err, "read file '%s'", issuerCertFile)
 	}
 
-	exist, err := t.CertificateRepository.FindZone(zone)
+	req := &pb.CreateZoneRequest{
+		CertProvider:      "custom",
+		Domains:           []string{args[0]},
+		Certificate:       certContents,
+		PrivateKey:        keyContents,
+		IssuerCertificate: issuerCertContents,
+	}
+
+	_, msg, err := t.CreateZone(context.Background(), req)
+	return msg, err
+}
+
+func (t *implCertificateService) CreateZone(ctx context.Context, req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error) {
+
+	if len(req.Domains) == 0 {
+		return nil, "", errors.New("empty domains")
+	}
+
+	switch req.CertProvider {
+	case "self":
+		return t.createSelfZone(req)
+	case "acme":
+		return t.createAcmeZone(ctx, req)
+	case "custom":
+		return t.createCustomZone(req)
+	default:
+		return nil, "", errors.Errorf("unknown cert provider '%s'", req.CertProvider)
+	}
+}
+
+func (t *implCertificateService) createSelfZone(req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error) {
+
+	zone, domains, err := t.zoneDomains(strings.Join(req.Domains, ","), req.Exact)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
-	if exist.Zone != "" {
-		return "", errors.Errorf("zone '%s' already exist", zone)
+	exist, err := t.CertificateRepository.FindZone(zone)
+	if err != nil {
+		return nil, "", err
 	}
 
-	domains := []string {
-		zone,
-		fmt.Sprintf("*.%s", zone),
+	if exist.Zone != "" {
+		return nil, "", errors.Errorf("zone '%s' already exist", zone)
 	}
 
 	selfSigner := "localhost"
-	if len(args) > 0 {
-		selfSigner = args[0]
+	if req.SelfSigner != "" {
+		selfSigner = req.SelfSigner
 	}
 
 	ss, err := t.CertificateRepository.FindSelfSigner(selfSigner)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	var warning string
@@ -708,17 +847,17 @@ func (t *implCertificateService) createSelfCert(args []string) (string, error) {
 
 	err = t.IssueSelfSignedCertificate(entry)
 	if err != nil {
-		return "", errors.Wrapf(err, "issue self signed certificate for zone '%s', domains '%v'", zone, domains)
+		return nil, "", errors.Wrapf(err, "issue self signed certificate for zone '%s', domains '%v'", zone, domains)
 	}
 
 	x509Cert, err := t.parseCertificate(entry.Certificates)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	err = t.CertificateRepository.SaveZone(entry)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	domains = certcrypto.ExtractDomains(x509Cert)
@@ -728,26 +867,21 @@ func (t *implCertificateService) createSelfCert(args []string) (string, error) {
 	if warning != "" {
 		msg = fmt.Sprintf("%s\n%s", msg, warning)
 	}
-	return msg, nil
+	return entry, msg, nil
 }
 
-func (t *implCertificateService) createAcmeCert(args []string) (string, error) {
+func (t *implCertificateService) createAcmeZone(ctx context.Context, req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error) {
 
-	if len(args) < 2 {
-		return fmt.Sprintf("Usage: ./%s cert create acme domain email [dns_provider]", t.Application.Name()), nil
-	}
+	email := strings.ToLower(req.AcmeEmail)
+	dnsProvider := strings.ToLower(req.DnsProvider)
 
-	domain := util.UnFqdn(args[0])
-	email := strings.ToLower(args[1])
-
-	var dnsProvider string
-	if len(args) > 2 {
-		dnsProvider = strings.ToLower(args[2])
+	if email == "" {
+		return nil, "", errors.New("empty acme email")
 	}
 
 	acc, err := t.CertificateRepository.FindAccount(email)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	var warning string
@@ -756,40 +890,30 @@ func (t *implCertificateService) createAcmeCert(args []string) (string, error) {
 		warning = fmt.Sprintf("Warning: ACME account '%s' was not found", email)
 	}
 
-	punycode, err := idna.Lookup.ToASCII(domain)
-	if err != nil {
-		return"", errors.Wrapf(err, "domain name '%s' contains invalid character", domain)
-	}
-
-	zone, err := util.ToZone(punycode)
+	zone, domains, err := t.zoneDomains(strings.Join(req.Domains, ","), req.Exact)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	exist, err := t.CertificateRepository.FindZone(zone)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	if exist.Zone != "" {
-		return "", errors.Errorf("zone '%s' already exist", zone)
-	}
-
-	domains := []string {
-		zone,
-		fmt.Sprintf("*.%s", zone),
+		return nil, "", errors.Errorf("zone '%s' already exist", zone)
 	}
 
 	if dnsProvider == "" {
-		dnsProvider, err = t.delectProviderFromWhois(zone)
+		dnsProvider, err = t.detectDomainsProvider(domains)
 		if err != nil {
-			return "", errors.Wrapf(err, "detect provider from whois for zone '%s", zone)
+			return nil, "", err
 		}
 	}
 
 	prov, ok := t.providerMap[dnsProvider]
 	if !ok {
-		return "", errors.Errorf("dns provider '%s' not found in supported list %+v", dnsProvider, t.providerList)
+		return nil, "", errors.Errorf("dns provider '%s' not found in supported list %+v", dnsProvider, t.providerList)
 	}
 
 	var ask error
@@ -807,19 +931,19 @@ func (t *implCertificateService) createAcmeCert(args []string) (string, error) {
 		DnsProvider: dnsProvider,
 	}
 
-	msg, err := t.IssueAcmeCertificate(entry)
+	msg, err := t.issueAcmeCertificate(ctx, entry)
 	if err != nil {
-		return "", errors.Wrapf(err, "issue acme certificate for zone '%s', domains '%v'", zone, domains)
+		return nil, "", errors.Wrapf(err, "issue acme certificate for zone '%s', domains '%v'", zone, domains)
 	}
 
 	x509Cert, err := t.parseCertificate(entry.Certificates)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	err = t.CertificateRepository.SaveZone(entry)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	domains = certcrypto.ExtractDomains(x509Cert)
@@ -832,73 +956,31 @@ func (t *implCertificateService) createAcmeCert(args []string) (string, error) {
 	if warning != "" {
 		msg = fmt.Sprintf("%s\n%s", msg, warning)
 	}
-	return msg, nil
+	return entry, msg, nil
 
 }
 
-func (t *implCertificateService) delectProviderFromWhois(zone string) (string, error) {
+func (t *implCertificateService) createCustomZone(req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error) {
 
-	whoisResult, err := t.WhoisService.Whois(zone)
-	if err != nil {
-		return "", errors.Wrapf(err, "get whois for zone '%s", zone)
-	}
-
-	whois := t.WhoisService.Parse(whoisResult)
-
-	for name, prov := range t.providerMap {
-
-		if prov.Detect(whois) {
-			return name, nil
-		}
-
-	}
-
-	return "", errors.Errorf("DNS provider not found for zone '%s' in whois response '%s'", zone, whoisResult)
-}
-
-func (t *implCertificateService) createCustomCert(args []string) (string, error) {
-
-	if len(args) < 4 {
-		return fmt.Sprintf("Usage: ./%s cert create custom domain cert_file key_file issuer_cert_file", t.Application.Name()), nil
-	}
-
-	domain := util.UnFqdn(args[0])
-	certFile := args[1]
-	keyFile := args[2]
-	issuerCertFile := args[3]
+	domain := util.UnFqdn(req.Domains[0])
 
 	punycode, err := idna.Lookup.ToASCII(domain)
 	if err != nil {
-		return"", errors.Wrapf(err, "domain name '%s' contains invalid character", domain)
+		return nil, "", errors.Wrapf(err, "domain name '%s' contains invalid character", domain)
 	}
 
 	zone, err := util.ToZone(punycode)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	exist, err := t.CertificateRepository.FindZone(zone)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	if exist.Zone != "" {
-		return "", errors.Errorf("zone '%s' already exist", zone)
-	}
-
-	certContents, err := ioutil.ReadFile(certFile)
-	if err != nil {
-		return "", errors.Wrapf(err, "read file '%s'", certFile)
-	}
-
-	keyContents, err := ioutil.ReadFile(keyFile)
-	if err != nil {
-		return "", errors.Wrapf(err, "read file '%s'", keyFile)
-	}
-
-	issuerCertContents, err := ioutil.ReadFile(issuerCertFile)
-	if err != nil {
-		return "", errors.Wrapf(err, "read file '%s'", issuerCertFile)
+		return nil, "", errors.Errorf("zone '%s' already exist", zone)
 	}
 
 	entry := &sprintpb.Zone{
@@ -906,15 +988,15 @@ func (t *implCertificateService) createCustomCert(args []string) (string, error)
 		CertProvider: "custom",
 		Certificates:  &sprintpb.Certificates{
 			Domain:            domain,
-			PrivateKey:        keyContents,
-			Certificate:       certContents,
-			IssuerCertificate: issuerCertContents,
+			PrivateKey:        req.PrivateKey,
+			Certificate:       req.Certificate,
+			IssuerCertificate: req.IssuerCertificate,
 		},
 	}
 
 	x509Cert, err := t.parseCertificate(entry.Certificates)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	domains := certcrypto.ExtractDomains(x509Cert)
@@ -924,11 +1006,99 @@ func (t *implCertificateService) createCustomCert(args []string) (string, error)
 
 	err = t.CertificateRepository.SaveZone(entry)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	msg := fmt.Sprintf("Uploaded certificate [%s] for domains %+v with %d hours remaining", zone, domains, int(timeLeft.Hours()))
-	return msg, nil
+	return entry, msg, nil
+}
+
+// zoneDomains resolves the zone name and the certificate domains. A single domain is reduced
+// to the registrable zone with its wildcard, a list of domains, a wildcard or the exact flag
+// keep the domains as given and the zone is named by the first one.
+func (t *implCertificateService) zoneDomains(list string, exact bool) (string, []string, error) {
+
+	domains, err := util.ParseDomains(list)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(domains) == 1 && !exact && !strings.HasPrefix(domains[0], "*.") {
+
+		zone, err := util.ToZone(domains[0])
+		if err != nil {
+			return "", nil, err
+		}
+
+		return zone, []string{zone, fmt.Sprintf("*.%s", zone)}, nil
+	}
+
+	return strings.TrimPrefix(domains[0], "*."), domains, nil
+}
+
+func removeFlag(args []string, flag string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == flag {
+			return append(args[:i:i], args[i+1:]...), true
+		}
+	}
+	return args, false
+}
+
+/**
+	The zone keeps a single DNS provider, so all domains must be served by the same one
+*/
+
+func (t *implCertificateService) detectDomainsProvider(domains []string) (string, error) {
+
+	var dnsProvider, first string
+	seen := make(map[string]bool)
+
+	for _, domain := range domains {
+
+		registrable, err := util.ToZone(strings.TrimPrefix(domain, "*."))
+		if err != nil {
+			return "", err
+		}
+
+		if seen[registrable] {
+			continue
+		}
+		seen[registrable] = true
+
+		name, err := t.delectProviderFromWhois(registrable)
+		if err != nil {
+			return "", errors.Wrapf(err, "detect provider from whois for zone '%s", registrable)
+		}
+
+		if dnsProvider == "" {
+			dnsProvider, first = name, registrable
+		} else if name != dnsProvider {
+			return "", errors.Errorf("zone '%s' is served by dns provider '%s', but zone '%s' by '%s', all domains must share one dns provider", first, dnsProvider, registrable, name)
+		}
+	}
+
+	return dnsProvider, nil
+}
+
+func (t *implCertificateService) delectProviderFromWhois(zone string) (string, error) {
+
+	whoisResult, err := t.WhoisService.Whois(zone)
+	if err != nil {
+		return "", errors.Wrapf(err, "get whois for zone '%s", zone)
+	}
+
+	whois := t.WhoisService.Parse(whoisResult)
+
+	for name, prov := range t.providerMap {
+
+		if prov.Detect(whois) {
+			return name, nil
+		}
+
+	}
+
+	return "", errors.Errorf("DNS provider not found for zone '%s' in whois response '%s'", zone, whoisResult)
 }
 
 func (t *implCertificateService) loadCertificate(zone string) (*x509.Certificate, error) {
@@ -1043,13 +1213,22 @@ func (t *implCertificateService) clientCert(args []string) (string, error) {
 
 }
 
+type clientRolesIssuer interface {
+	IssueClientCertWithRoles(cn string, password string, roles []string) (sprint.IssuedCertificate, []byte, error)
+}
+
 func (t *implCertificateService) installClientCert(args []string) (string, error) {
 
 	if len(args) < 1 {
-		return fmt.Sprintf("Usage: ./%s cert client install zone", t.Application.Name()), nil
+		return fmt.Sprintf("Usage: ./%s cert client install zone [roles]", t.Application.Name()), nil
 	}
 
 	zone := args[0]
+	// admin credentials must be requested explicitly
+	roles := []string{"USER"}
+	if len(args) > 1 {
+		roles = strings.Split(args[1], ",")
+	}
 
 	entry, err := t.CertificateRepository.FindZone(zone)
 	if err != nil {
@@ -1077,7 +1256,12 @@ func (t *implCertificateService) installClientCert(args []string) (string, error
 		return "", err
 	}
 
-	issuedCert, _, err := issuer.IssueClientCert(entry.Zone, "")
+	var issuedCert sprint.IssuedCertificate
+	if rolesIssuer, ok := issuer.(clientRolesIssuer); ok {
+		issuedCert, _, err = rolesIssuer.IssueClientCertWithRoles(entry.Zone, "", roles)
+	} else {
+		issuedCert, _, err = issuer.IssueClientCert(entry.Zone, "")
+	}
 	if err != nil {
 		return "", err
 	}
@@ -1105,7 +1289,7 @@ func (t *implCertificateService) installClientCert(args []string) (string, error
 func (t *implCertificateService) acmeCommand(args []string) (string, error) {
 
 	if len(args) < 1 {
-		return fmt.Sprintf("Usage: ./%s cert acme [list create upload dump]", t.Application.Name()), nil
+		return fmt.Sprintf("Usage: ./%s cert acme [list create upload dump rollover deactivate]", t.Application.Name()), nil
 	}
 
 	cmd := args[0]
@@ -1120,6 +1304,10 @@ func (t *implCertificateService) acmeCommand(args []string) (string, error) {
 		return t.acmeUpload(args)
 	case "dump":
 		return t.acmeDump(args)
+	case "rollover":
+		return t.acmeRollover(args)
+	case "deactivate":
+		return t.acmeDeactivate(args)
 
 	default:
 		return "", errors.Errorf("unknown acme command: %s", cmd)
@@ -1200,10 +1388,34 @@ func (t *implCertificateService) acmeDump(args []string) (string, error) {
 	return protojson.Format(entry), nil
 }
 
+func (t *implCertificateService) acmeRollover(args []string) (string, error) {
+	if len(args) < 1 {
+		return fmt.Sprintf("Usage: ./%s cert acme rollover email", t.Application.Name()), nil
+	}
+	email := strings.ToLower(args[0])
+	if err := t.RolloverAcmeAccount(email); err != nil {
+		return "", err
+	} else {
+		return fmt.Sprintf("Rolled over key of ACME Account for %s", email), nil
+	}
+}
+
+func (t *implCertificateService) acmeDeactivate(args []string) (string, error) {
+	if len(args) < 1 {
+		return fmt.Sprintf("Usage: ./%s cert acme deactivate email", t.Application.Name()), nil
+	}
+	email := strings.ToLower(args[0])
+	if err := t.DeactivateAcmeAccount(email); err != nil {
+		return "", err
+	} else {
+		return fmt.Sprintf("Deactivated ACME Account for %s", email), nil
+	}
+}
+
 func (t *implCertificateService) selfCommand(args []string) (string, error) {
 
 	if len(args) < 1 {
-		return fmt.Sprintf("Usage: ./%s cert self [list create upload dump]", t.Application.Name()), nil
+		return fmt.Sprintf("Usage: ./%s cert self [list create upload dump sign issued rotate retire]", t.Application.Name()), nil
 	}
 
 	cmd := args[0]
@@ -1218,6 +1430,14 @@ func (t *implCertificateService) selfCommand(args []string) (string, error) {
 		return t.selfUpload(args)
 	case "dump":
 		return t.selfDump(args)
+	case "sign":
+		return t.selfSign(args)
+	case "issued":
+		return t.selfIssued(args)
+	case "rotate":
+		return t.selfRotate(args)
+	case "retire":
+		return t.selfRetire(args)
 
 	default:
 		return "", errors.Errorf("unknown self command: %s", cmd)
@@ -1227,10 +1447,13 @@ func (t *implCertificateService) selfCommand(args []string) (string, error) {
 
 func (t *implCertificateService) selfList(args []string) (string, error) {
 	var out strings.Builder
-	out.WriteString("Name\n")
+	out.WriteString("Name,NotAfter\n")
 	err := t.CertificateRepository.ListSelfSigners("", func(self *sprintpb.SelfSigner) bool {
-		out.WriteString(self.Name)
-		out.WriteByte('\n')
+		var notAfter string
+		if leaf, err := parseLeafCertificate(self.Certificate); err == nil {
+			notAfter = leaf.NotAfter.Format(time.RFC3339)
+		}
+		out.WriteString(fmt.Sprintf("%s,%s\n", self.Name, notAfter))
 		return true
 	})
 	return out.String(), err
@@ -1299,5 +1522,73 @@ func (t *implCertificateService) selfDump(args []string) (string, error) {
 	return protojson.Format(entry), nil
 }
 
+func (t *implCertificateService) selfSign(args []string) (string, error) {
+
+	usage := fmt.Sprintf("Usage: ./%s cert self sign csr.pem [--days n] [--client|--server] [--signer name]", t.Application.Name())
+
+	req := &pb.SignCSRRequest{}
+	var csrFile string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--days":
+			if i+1 == len(args) {
+				return usage, nil
+			}
+			i++
+			days, err := strconv.Atoi(args[i])
+			if err != nil {
+				return "", errors.Errorf("invalid days '%s'", args[i])
+			}
+			req.Days = int32(days)
+		case "--signer":
+			if i+1 == len(args) {
+				return usage, nil
+			}
+			i++
+			req.Signer = strings.ToLower(args[i])
+		case "--client":
+			req.Profile = "client"
+		case "--server":
+			req.Profile = "server"
+		default:
+			if strings.HasPrefix(args[i], "--") || csrFile != "" {
+				return usage, nil
+			}
+			csrFile = args[i]
+		}
+	}
+
+	if csrFile == "" {
+		return usage, nil
+	}
+
+	var err error
+	req.Csr, err = ioutil.ReadFile(csrFile)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.CertificateAuthority.SignCSR(req, "console")
+	if err != nil {
+		return "", err
+	}
+
+	return string(resp.Certificate) + string(resp.IssuerCertificate), nil
+}
+
+func (t *implCertificateService) selfIssued(args []string) (string, error) {
 
+	var signer string
+	if len(args) > 0 {
+		signer = strings.ToLower(args[0])
+	}
 
+	var out strings.Builder
+	out.WriteString("Signer,Serial,Profile,Subject,DNSNames,IPAddresses,NotAfter,IssuedBy\n")
+	err := t.CertificateAuthority.ListIssued(signer, func(entry *pb.IssuedCertificate) bool {
+		out.WriteString(fmt.Sprintf("%s,%s,%s,%s,%+v,%+v,%v,%s\n", entry.Signer, entry.Serial, entry.Profile, entry.Subject, entry.DnsNames, entry.IpAddresses, time.Unix(entry.NotAfter, 0), entry.IssuedBy))
+		return true
+	})
+	return out.String(), err
+}
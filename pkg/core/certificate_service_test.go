@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestDoAcmeCallSpan(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx, parent := util.StartSpan(context.Background(), "RenewZone")
+
+	service := &implCertificateService{}
+	service.doAcmeCall(ctx, "AcmeCertificateRenew", []attribute.KeyValue{attribute.String("acme.zone", "example.com")}, func() error {
+		return errors.New("rate limited")
+	})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Equal(t, 2, len(spans))
+	require.Equal(t, "AcmeCertificateRenew", spans[0].Name())
+	require.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,314 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/store"
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ctKnownPrefix    = "ct-known"
+	ctAlertedPrefix  = "ct-alerted"
+	ctBaselinePrefix = "ct-baseline"
+)
+
+type implCertificateTransparency struct {
+	Application sprint.Application `inject`
+	Log         *zap.Logger        `inject`
+
+	CertificateRepository sprint.CertificateRepository `inject`
+	CertificateMonitor    api.CertificateMonitor       `inject`
+	JobService            sprint.JobService            `inject`
+
+	// pluggable client, crt.sh compatible client is used by default
+	CTLogClient           api.CTLogClient              `inject:"optional"`
+
+	Enabled    bool           `value:"certificate.ct.enabled,default=false"`
+	SearchUrl  string         `value:"certificate.ct.url,default=https://crt.sh/?q=%s&output=json&exclude=expired"`
+	Interval   time.Duration  `value:"certificate.ct.interval,default=6h"`
+	Delay      time.Duration  `value:"certificate.ct.delay,default=5m"`
+	Timeout    time.Duration  `value:"certificate.ct.timeout,default=30s"`
+
+	checkMu   sync.Mutex
+
+	job       *periodicJob
+
+	lastCheck     atomic.Int64
+	lastErr       atomic.String
+	zones         atomic.Int32
+	entries       atomic.Int32
+	unexpected    atomic.Int64
+	searchErrors  atomic.Int64
+}
+
+func CertificateTransparency() api.CertificateTransparency {
+	return &implCertificateTransparency{}
+}
+
+func (t *implCertificateTransparency) BeanName() string {
+	return "certificate_transparency"
+}
+
+func (t *implCertificateTransparency) PostConstruct() error {
+
+	if !t.Enabled {
+		return nil
+	}
+
+	if t.CTLogClient == nil {
+		t.CTLogClient = CTLogClient(t.SearchUrl, &http.Client{
+			Timeout: t.Timeout,
+		})
+	}
+
+	t.job = &periodicJob{
+		name:     t.BeanName(),
+		delay:    t.Delay,
+		interval: t.Interval,
+		execute: func(ctx context.Context) error {
+			return t.Check()
+		},
+		run: t.run,
+	}
+	return t.job.start(t.JobService)
+}
+
+func (t *implCertificateTransparency) Destroy() error {
+	t.job.stop()
+	return nil
+}
+
+func (t *implCertificateTransparency) run() {
+	if err := t.Check(); err != nil {
+		t.Log.Error("CertificateTransparencyCheck", zap.Error(err))
+	}
+}
+
+func (t *implCertificateTransparency) GetStats(cb func(name, value string) bool) error {
+	cb("enabled", strconv.FormatBool(t.Enabled))
+	if lastCheck := t.lastCheck.Load(); lastCheck != 0 {
+		cb("lastCheck", time.Unix(lastCheck, 0).String())
+	}
+	if lastErr := t.lastErr.Load(); lastErr != "" {
+		cb("lastErr", lastErr)
+	}
+	cb("zones", strconv.Itoa(int(t.zones.Load())))
+	cb("entries", strconv.Itoa(int(t.entries.Load())))
+	cb("unexpected", strconv.FormatInt(t.unexpected.Load(), 10))
+	cb("searchErrors", strconv.FormatInt(t.searchErrors.Load(), 10))
+	return nil
+}
+
+func (t *implCertificateTransparency) Check() (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+		if err != nil {
+			t.lastErr.Store(err.Error())
+		} else {
+			t.lastErr.Store("")
+		}
+	}()
+
+	if t.CTLogClient == nil {
+		return errors.New("certificate transparency check is disabled")
+	}
+
+	t.checkMu.Lock()
+	defer t.checkMu.Unlock()
+
+	now := time.Now()
+
+	known, err := t.listSerials(ctKnownPrefix)
+	if err != nil {
+		return err
+	}
+
+	alerted, err := t.listSerials(ctAlertedPrefix)
+	if err != nil {
+		return err
+	}
+
+	// keys are the searched domains
+	baseline, err := t.listSerials(ctBaselinePrefix)
+	if err != nil {
+		return err
+	}
+
+	var acmeZones []*sprintpb.Zone
+	err = t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
+		if entry.CertProvider == "acme" {
+			acmeZones = append(acmeZones, entry)
+		}
+		if entry.Certificates == nil {
+			return true
+		}
+		leaf, err := parseLeafCertificate(entry.Certificates.Certificate)
+		if err != nil {
+			return true
+		}
+		// remember all certificates ever issued for our zones
+		serial := serialOf(leaf.SerialNumber)
+		if !known[serial] {
+			known[serial] = true
+			if err := t.saveSerial(ctKnownPrefix, serial, entry.Zone); err != nil {
+				t.Log.Error("CertificateTransparencySave", zap.String("zone", entry.Zone), zap.String("serial", serial), zap.Error(err))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.Interval)
+	defer cancel()
+
+	var entries int32
+	var searchErr error
+	for _, zone := range acmeZones {
+
+		for _, domain := range searchDomains(zone.Domains) {
+
+			list, err := t.CTLogClient.Search(ctx, domain)
+			if err != nil {
+				t.searchErrors.Inc()
+				t.Log.Warn("CertificateTransparencySearch", zap.String("zone", zone.Zone), zap.String("domain", domain), zap.Error(err))
+				searchErr = err
+				continue
+			}
+
+			if !baseline[domain] {
+				t.seedBaseline(zone.Zone, domain, list, known)
+				baseline[domain] = true
+				entries += int32(len(list))
+				continue
+			}
+
+			for _, entry := range list {
+				entries++
+				if known[entry.Serial] || alerted[entry.Serial] || entry.NotAfter.Before(now) {
+					continue
+				}
+				alerted[entry.Serial] = true
+
+				t.unexpected.Inc()
+				t.Log.Warn("UnexpectedCertificate",
+					zap.String("zone", zone.Zone),
+					zap.String("id", entry.ID),
+					zap.String("serial", entry.Serial),
+					zap.String("issuer", entry.Issuer),
+					zap.Strings("domains", entry.DNSNames))
+
+				event := &api.CertificateEvent{
+					Zone:     zone.Zone,
+					Domains:  entry.DNSNames,
+					NotAfter: entry.NotAfter,
+					DaysLeft: int(entry.NotAfter.Sub(now) / day),
+					Reason:   fmt.Sprintf("unexpected certificate %s in CT log", entry.ID),
+					Serial:   entry.Serial,
+					Issuer:   entry.Issuer,
+				}
+
+				if err := t.CertificateMonitor.Notify(event); err != nil {
+					t.Log.Error("CertificateTransparencyNotify", zap.String("zone", zone.Zone), zap.Error(err))
+				}
+
+				// do not repeat notifications on every check
+				if err := t.saveSerial(ctAlertedPrefix, entry.Serial, zone.Zone); err != nil {
+					t.Log.Error("CertificateTransparencySave", zap.String("zone", zone.Zone), zap.String("serial", entry.Serial), zap.Error(err))
+				}
+			}
+		}
+	}
+
+	t.zones.Store(int32(len(acmeZones)))
+	t.entries.Store(entries)
+	t.lastCheck.Store(now.Unix())
+	return searchErr
+}
+
+// seedBaseline accepts the certificates already logged for the domain on its first search,
+// only the certificates logged after it are reported as unexpected.
+func (t *implCertificateTransparency) seedBaseline(zone, domain string, list []*api.CTLogEntry, known map[string]bool) {
+
+	var added int
+	for _, entry := range list {
+		if known[entry.Serial] {
+			continue
+		}
+		known[entry.Serial] = true
+		added++
+		if err := t.saveSerial(ctKnownPrefix, entry.Serial, zone); err != nil {
+			t.Log.Error("CertificateTransparencySave", zap.String("zone", zone), zap.String("serial", entry.Serial), zap.Error(err))
+		}
+	}
+
+	if err := t.saveSerial(ctBaselinePrefix, domain, zone); err != nil {
+		t.Log.Error("CertificateTransparencySave", zap.String("zone", zone), zap.String("domain", domain), zap.Error(err))
+	}
+
+	t.Log.Info("CertificateTransparencyBaseline", zap.String("zone", zone), zap.String("domain", domain), zap.Int("entries", len(list)), zap.Int("added", added))
+}
+
+// searchDomains returns the unique domains to search, wildcard domains are searched by the parent name.
+func searchDomains(domains []string) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(domain), "*.")
+		if !seen[domain] {
+			seen[domain] = true
+			list = append(list, domain)
+		}
+	}
+	return list
+}
+
+func (t *implCertificateTransparency) listSerials(prefix string) (map[string]bool, error) {
+	serials := make(map[string]bool)
+	keyPrefix := fmt.Sprintf("%s:%s:", CertBucket, prefix)
+	err := t.CertificateRepository.Backend().Enumerate(context.Background()).ByPrefix("%s:%s:", CertBucket, prefix).WithBatchSize(100).Do(func(entry *store.RawEntry) bool {
+		serials[strings.TrimPrefix(string(entry.Key), keyPrefix)] = true
+		return true
+	})
+	return serials, err
+}
+
+func (t *implCertificateTransparency) saveSerial(prefix, serial, zone string) error {
+	return t.CertificateRepository.Backend().Set(context.Background()).ByKey("%s:%s:%s", CertBucket, prefix, serial).String(zone)
+}
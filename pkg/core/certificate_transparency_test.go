@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"github.com/codeallergy/cachestore"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintpb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type stubCTLog struct {
+	entries []*api.CTLogEntry
+	err     error
+}
+
+func (t *stubCTLog) Search(ctx context.Context, domain string) ([]*api.CTLogEntry, error) {
+	return t.entries, t.err
+}
+
+type stubNotifyMonitor struct {
+	api.CertificateMonitor
+	events []*api.CertificateEvent
+}
+
+func (t *stubNotifyMonitor) Notify(event *api.CertificateEvent) error {
+	t.events = append(t.events, event)
+	return nil
+}
+
+func TestCertificateTransparencyCheck(t *testing.T) {
+
+	now := time.Now()
+
+	zone := &sprintpb.Zone{Zone: "example.com", Domains: []string{"example.com", "*.example.com"}, CertProvider: "acme"}
+	zone.Certificates = &sprintpb.Certificates{Certificate: testCertificatePEM(t, now.Add(60*day))}
+	leaf, err := parseLeafCertificate(zone.Certificates.Certificate)
+	require.NoError(t, err)
+	own := serialOf(leaf.SerialNumber)
+
+	logEntry := func(serial string, notAfter time.Time) *api.CTLogEntry {
+		return &api.CTLogEntry{ID: serial, Serial: serial, Issuer: "C=US, O=Let's Encrypt, CN=R3", DNSNames: []string{"example.com"}, NotAfter: notAfter}
+	}
+
+	ctLog := &stubCTLog{err: errors.New("unavailable")}
+	monitor := &stubNotifyMonitor{}
+
+	ct := &implCertificateTransparency{
+		Log:                   zap.NewNop(),
+		CertificateRepository: &stubZoneRepository{backend: cachestore.New("test-storage"), zones: []*sprintpb.Zone{zone}},
+		CertificateMonitor:    monitor,
+		CTLogClient:           ctLog,
+		Interval:              time.Minute,
+	}
+
+	// failed search does not seed the baseline
+	require.Error(t, ct.Check())
+	require.Empty(t, monitor.events)
+
+	// certificates logged before the first search are the baseline
+	ctLog.err = nil
+	ctLog.entries = []*api.CTLogEntry{logEntry(own, leaf.NotAfter), logEntry("a1", now.Add(30*day))}
+	require.NoError(t, ct.Check())
+	require.Empty(t, monitor.events)
+	require.Equal(t, int64(0), ct.unexpected.Load())
+
+	// new certificate is unexpected, the expired one is ignored
+	ctLog.entries = append(ctLog.entries, logEntry("b2", now.Add(90*day)), logEntry("c3", now.Add(-day)))
+	require.NoError(t, ct.Check())
+	require.Equal(t, 1, len(monitor.events))
+	require.Equal(t, "b2", monitor.events[0].Serial)
+	require.Equal(t, "example.com", monitor.events[0].Zone)
+	require.Equal(t, int64(1), ct.unexpected.Load())
+
+	// alerted only once
+	require.NoError(t, ct.Check())
+	require.Equal(t, 1, len(monitor.events))
+
+	// own certificates issued later are expected
+	renewed := testCertificatePEM(t, now.Add(90*day))
+	zone.Certificates = &sprintpb.Certificates{Certificate: renewed}
+	leaf, err = parseLeafCertificate(renewed)
+	require.NoError(t, err)
+	ctLog.entries = append(ctLog.entries, logEntry(serialOf(leaf.SerialNumber), leaf.NotAfter))
+	require.NoError(t, ct.Check())
+	require.Equal(t, 1, len(monitor.events))
+}
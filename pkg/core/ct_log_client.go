@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const ctLogTimeLayout = "2006-01-02T15:04:05"
+
+type implCTLogClient struct {
+	url     string
+	client  *http.Client
+}
+
+/**
+Creates CT log search client for crt.sh compatible JSON API, the url has '%s' placeholder for the domain.
+*/
+
+func CTLogClient(searchUrl string, client *http.Client) api.CTLogClient {
+	return &implCTLogClient{
+		url:    searchUrl,
+		client: client,
+	}
+}
+
+type ctLogRecord struct {
+	ID          json.Number `json:"id"`
+	IssuerName  string      `json:"issuer_name"`
+	CommonName  string      `json:"common_name"`
+	NameValue   string      `json:"name_value"`
+	Serial      string      `json:"serial_number"`
+	NotBefore   string      `json:"not_before"`
+	NotAfter    string      `json:"not_after"`
+}
+
+func (t *implCTLogClient) Search(ctx context.Context, domain string) ([]*api.CTLogEntry, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(t.url, url.QueryEscape(domain)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d from CT log for '%s'", resp.StatusCode, domain)
+	}
+
+	var records []*ctLogRecord
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, errors.Wrapf(err, "decode CT log response for '%s'", domain)
+	}
+
+	var list []*api.CTLogEntry
+	for _, r := range records {
+
+		serial, err := normalizeSerial(r.Serial)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid serial number '%s' of CT log entry %s", r.Serial, r.ID)
+		}
+
+		entry := &api.CTLogEntry{
+			ID:         r.ID.String(),
+			Issuer:     r.IssuerName,
+			CommonName: r.CommonName,
+			Serial:     serial,
+		}
+
+		for _, name := range strings.Split(r.NameValue, "\n") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				entry.DNSNames = append(entry.DNSNames, name)
+			}
+		}
+
+		if entry.NotBefore, err = time.Parse(ctLogTimeLayout, r.NotBefore); err != nil {
+			return nil, errors.Wrapf(err, "invalid not_before of CT log entry %s", r.ID)
+		}
+
+		if entry.NotAfter, err = time.Parse(ctLogTimeLayout, r.NotAfter); err != nil {
+			return nil, errors.Wrapf(err, "invalid not_after of CT log entry %s", r.ID)
+		}
+
+		list = append(list, entry)
+	}
+
+	return list, nil
+}
+
+func normalizeSerial(hex string) (string, error) {
+	hex = strings.ReplaceAll(hex, ":", "")
+	n, ok := new(big.Int).SetString(hex, 16)
+	if !ok {
+		return "", errors.New("not a hex number")
+	}
+	return n.Text(16), nil
+}
+
+func serialOf(n *big.Int) string {
+	return n.Text(16)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core_test
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/core"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const stubLogResponse = `[
+  {
+    "issuer_ca_id": 183267,
+    "issuer_name": "C=US, O=Let's Encrypt, CN=R3",
+    "common_name": "example.com",
+    "name_value": "*.example.com\nexample.com",
+    "id": 8511437153,
+    "entry_timestamp": "2023-01-28T10:05:12.532",
+    "not_before": "2023-01-28T09:05:12",
+    "not_after": "2023-04-28T09:05:11",
+    "serial_number": "04f1a83b92ce6e42a5d1e2ab0a0b4d9ce96e"
+  }
+]`
+
+func TestCTLogClient(t *testing.T) {
+
+	var query string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(stubLogResponse))
+	}))
+	defer stub.Close()
+
+	client := core.CTLogClient(stub.URL+"/?q=%s&output=json", stub.Client())
+
+	list, err := client.Search(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", query)
+	require.Equal(t, 1, len(list))
+
+	entry := list[0]
+	require.Equal(t, "8511437153", entry.ID)
+	require.Equal(t, "C=US, O=Let's Encrypt, CN=R3", entry.Issuer)
+	require.Equal(t, []string{"*.example.com", "example.com"}, entry.DNSNames)
+	require.Equal(t, "4f1a83b92ce6e42a5d1e2ab0a0b4d9ce96e", entry.Serial)
+	require.Equal(t, time.Date(2023, 4, 28, 9, 5, 11, 0, time.UTC), entry.NotAfter)
+}
+
+func TestCTLogClientStatus(t *testing.T) {
+
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer stub.Close()
+
+	client := core.CTLogClient(stub.URL+"/?q=%s", stub.Client())
+
+	_, err := client.Search(context.Background(), "example.com")
+	require.Error(t, err)
+}
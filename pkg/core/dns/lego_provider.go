@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+package dns
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/lego"
+	legodns "github.com/go-acme/lego/v4/providers/dns"
+	"github.com/pkg/errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const legoPropertyPrefix = "dns.lego."
+
+/**
+	Defaults of the well known lego providers, the name server patterns are used to detect the provider from whois
+	and could be overridden by 'dns.lego.<name>.nservers' property, the zone token goes to the tokenEnv variable
+	that could be overridden by 'dns.lego.<name>.token-env' property
+*/
+
+type legoDefaults struct {
+	envPrefix  string
+	tokenEnv   string
+	nservers   []string
+}
+
+var legoProviders = map[string]legoDefaults {
+	"cloudflare":   {tokenEnv: "CLOUDFLARE_DNS_API_TOKEN", nservers: []string{".ns.cloudflare.com"}},
+	"digitalocean": {envPrefix: "DO_", tokenEnv: "DO_AUTH_TOKEN", nservers: []string{".digitalocean.com"}},
+	"route53":      {envPrefix: "AWS_", nservers: []string{".awsdns-"}},
+	"godaddy":      {nservers: []string{".domaincontrol.com"}},
+	"hetzner":      {tokenEnv: "HETZNER_API_KEY", nservers: []string{".hetzner.com", ".second-ns.de", ".second-ns.com"}},
+	"linode":       {tokenEnv: "LINODE_TOKEN", nservers: []string{".linode.com"}},
+	"vultr":        {tokenEnv: "VULTR_API_KEY", nservers: []string{".vultr.com"}},
+	"dnsimple":     {tokenEnv: "DNSIMPLE_OAUTH_TOKEN", nservers: []string{".dnsimple.com"}},
+	"namecheap":    {tokenEnv: "NAMECHEAP_API_KEY", nservers: []string{".registrar-servers.com"}},
+	"gandiv5":      {tokenEnv: "GANDIV5_API_KEY", nservers: []string{".gandi.net"}},
+	"ovh":          {nservers: []string{".ovh.net"}},
+}
+
+// lego providers read the configuration only from the process environment
+var legoEnvMutex sync.Mutex
+
+type implLegoProviderFactory struct {
+	Properties   glue.Properties  `inject`
+
+	// record management clients for the generic providers
+	ClientFactories  []api.DNSProviderClientFactory  `inject:"optional"`
+
+	providers  []sprint.DNSProvider
+}
+
+/**
+	Creates lego DNS provider for every name found in 'dns.lego.<name>.<key>' properties, like 'cloudflare' or 'route53'.
+
+	The keys are the environment variables of the lego provider without the provider prefix, for example
+	'dns.lego.cloudflare.dns-api-token' sets CLOUDFLARE_DNS_API_TOKEN, already set variables are used as is.
+*/
+
+func LegoProviderFactory() api.DNSProviderFactory {
+	return &implLegoProviderFactory{}
+}
+
+func (t *implLegoProviderFactory) BeanName() string {
+	return "lego_provider_factory"
+}
+
+func (t *implLegoProviderFactory) PostConstruct() error {
+
+	names := make(map[string]bool)
+	for _, key := range t.Properties.Keys() {
+		if !strings.HasPrefix(key, legoPropertyPrefix) {
+			continue
+		}
+		if i := strings.IndexByte(key[len(legoPropertyPrefix):], '.'); i > 0 {
+			names[strings.ToLower(key[len(legoPropertyPrefix):len(legoPropertyPrefix)+i])] = true
+		}
+	}
+
+	var list []string
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+
+	for _, name := range list {
+		t.providers = append(t.providers, &implLegoProvider{
+			name:            name,
+			defaults:        legoProviders[name],
+			properties:      t.Properties,
+			clientFactories: t.ClientFactories,
+		})
+	}
+
+	return nil
+}
+
+func (t *implLegoProviderFactory) Providers() []sprint.DNSProvider {
+	return t.providers
+}
+
+type implLegoProvider struct {
+	name             string
+	defaults         legoDefaults
+	properties       glue.Properties
+	clientFactories  []api.DNSProviderClientFactory
+}
+
+func (t *implLegoProvider) BeanName() string {
+	return t.name + "_provider"
+}
+
+func (t *implLegoProvider) Detect(whois *sprint.Whois) bool {
+
+	patterns := t.defaults.nservers
+	if list := t.properties.GetString(t.propertyKey("nservers"), ""); list != "" {
+		patterns = strings.Split(list, ";")
+	}
+
+	for _, ns := range whois.NServer {
+		ns = strings.ToLower(ns)
+		for _, pattern := range patterns {
+			if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" && strings.Contains(ns, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *implLegoProvider) RegisterChallenge(legoClient interface{}, token string) error {
+
+	client, ok := legoClient.(*lego.Client)
+	if !ok {
+		return errors.Errorf("expected *lego.Client instance")
+	}
+
+	prov, err := t.challengeProvider(token)
+	if err != nil {
+		return err
+	}
+
+	return client.Challenge.SetDNS01Provider(prov)
+}
+
+func (t *implLegoProvider) NewClient() (sprint.DNSProviderClient, error) {
+
+	for _, factory := range t.clientFactories {
+		if strings.ToLower(factory.ProviderName()) == t.name {
+			return factory.NewClient()
+		}
+	}
+
+	prov, err := t.challengeProvider("")
+	if err != nil {
+		return nil, err
+	}
+
+	if client, ok := prov.(sprint.DNSProviderClient); ok {
+		return client, nil
+	}
+
+	return nil, errors.Errorf("dns provider '%s' does not support record management", t.name)
+}
+
+func (t *implLegoProvider) challengeProvider(token string) (challenge.Provider, error) {
+
+	env, err := t.environment(token)
+	if err != nil {
+		return nil, err
+	}
+
+	legoEnvMutex.Lock()
+	defer legoEnvMutex.Unlock()
+
+	restore := make(map[string]*string)
+	defer func() {
+		for key, value := range restore {
+			if value != nil {
+				os.Setenv(key, *value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}()
+
+	for key, value := range env {
+		prev, ok := os.LookupEnv(key)
+		if ok && (token == "" || key != t.tokenEnv()) {
+			// explicit system environment has priority over properties
+			continue
+		}
+		if ok {
+			restore[key] = &prev
+		} else {
+			restore[key] = nil
+		}
+		os.Setenv(key, value)
+	}
+
+	prov, err := legodns.NewDNSChallengeProviderByName(t.name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "create lego dns provider '%s'", t.name)
+	}
+
+	return prov, nil
+}
+
+/**
+	Maps 'dns.lego.<name>.<key>' properties to the lego environment variables of the provider with the zone token
+*/
+
+func (t *implLegoProvider) environment(token string) (map[string]string, error) {
+
+	envPrefix := t.defaults.envPrefix
+	if envPrefix == "" {
+		envPrefix = strings.ToUpper(t.name) + "_"
+	}
+
+	prefix := t.propertyKey("")
+	env := make(map[string]string)
+
+	for _, key := range t.properties.Keys() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		suffix := key[len(prefix):]
+		if suffix == "" || suffix == "nservers" || suffix == "token-env" {
+			continue
+		}
+		envKey := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(suffix))
+		if !strings.HasPrefix(envKey, envPrefix) {
+			envKey = envPrefix + envKey
+		}
+		env[envKey] = t.properties.GetString(key, "")
+	}
+
+	if token != "" {
+		tokenEnv := t.tokenEnv()
+		if tokenEnv == "" {
+			return nil, errors.Errorf("dns provider '%s' does not have token variable, set '%s' property", t.name, t.propertyKey("token-env"))
+		}
+		env[tokenEnv] = token
+	}
+
+	return env, nil
+}
+
+func (t *implLegoProvider) tokenEnv() string {
+	return t.properties.GetString(t.propertyKey("token-env"), t.defaults.tokenEnv)
+}
+
+func (t *implLegoProvider) propertyKey(suffix string) string {
+	return legoPropertyPrefix + t.name + "." + suffix
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+package dns
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+type stubClientFactory struct {
+	name   string
+	client sprint.DNSProviderClient
+}
+
+func (t *stubClientFactory) ProviderName() string {
+	return t.name
+}
+
+func (t *stubClientFactory) NewClient() (sprint.DNSProviderClient, error) {
+	return t.client, nil
+}
+
+func newLegoFactory(props map[string]string) (*implLegoProviderFactory, error) {
+	properties := glue.NewProperties()
+	for key, value := range props {
+		properties.Set(key, value)
+	}
+	factory := &implLegoProviderFactory{Properties: properties}
+	return factory, factory.PostConstruct()
+}
+
+func TestLegoProviderFactory(t *testing.T) {
+
+	factory, err := newLegoFactory(map[string]string{
+		"dns.lego.hetzner.api-key":             "secret",
+		"dns.lego.hetzner.ttl":                 "600",
+		"dns.lego.hetzner.propagation_timeout": "120",
+		"dns.lego.route53.aws-region":          "us-east-1",
+		"dns.lego.route53.max-retries":         "3",
+		"dns.other.key":                        "value",
+	})
+	require.NoError(t, err)
+
+	providers := factory.Providers()
+	require.Equal(t, 2, len(providers))
+	require.Equal(t, "hetzner_provider", providers[0].BeanName())
+	require.Equal(t, "route53_provider", providers[1].BeanName())
+
+	prov := providers[0].(*implLegoProvider)
+
+	env, err := prov.environment("")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"HETZNER_API_KEY":             "secret",
+		"HETZNER_TTL":                 "600",
+		"HETZNER_PROPAGATION_TIMEOUT": "120",
+	}, env)
+
+	// zone token overrides the configured key
+	env, err = prov.environment("zone-token")
+	require.NoError(t, err)
+	require.Equal(t, "zone-token", env["HETZNER_API_KEY"])
+
+	env, err = providers[1].(*implLegoProvider).environment("")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"AWS_REGION":      "us-east-1",
+		"AWS_MAX_RETRIES": "3",
+	}, env)
+
+	_, err = prov.challengeProvider("zone-token")
+	require.NoError(t, err)
+
+	// the environment is restored after the provider is created
+	_, ok := os.LookupEnv("HETZNER_API_KEY")
+	require.False(t, ok)
+	_, ok = os.LookupEnv("HETZNER_TTL")
+	require.False(t, ok)
+}
+
+func TestLegoProviderFactoryErrors(t *testing.T) {
+
+	factory, err := newLegoFactory(map[string]string{"dns.lego.unknown.key": "value"})
+	require.NoError(t, err)
+	_, err = factory.Providers()[0].(*implLegoProvider).challengeProvider("")
+	require.Error(t, err)
+
+	factory, err = newLegoFactory(map[string]string{"dns.lego.hetzner.ttl": "600"})
+	require.NoError(t, err)
+	_, err = factory.Providers()[0].(*implLegoProvider).challengeProvider("")
+	require.Error(t, err)
+
+	// route53 does not have the default token variable
+	factory, err = newLegoFactory(map[string]string{"dns.lego.route53.max-retries": "3"})
+	require.NoError(t, err)
+	_, err = factory.Providers()[0].(*implLegoProvider).environment("token")
+	require.Error(t, err)
+
+	factory, err = newLegoFactory(map[string]string{
+		"dns.lego.route53.max-retries": "3",
+		"dns.lego.route53.token-env":   "AWS_SESSION_TOKEN",
+	})
+	require.NoError(t, err)
+	env, err := factory.Providers()[0].(*implLegoProvider).environment("token")
+	require.NoError(t, err)
+	require.Equal(t, "token", env["AWS_SESSION_TOKEN"])
+}
+
+func TestLegoProviderDetect(t *testing.T) {
+
+	factory, err := newLegoFactory(map[string]string{
+		"dns.lego.cloudflare.dns-api-token": "token",
+		"dns.lego.hetzner.api-key":          "secret",
+		"dns.lego.hetzner.nservers":         "ns.example.net",
+	})
+	require.NoError(t, err)
+
+	cloudflare, hetzner := factory.Providers()[0], factory.Providers()[1]
+
+	whois := &sprint.Whois{NServer: []string{"ADA.NS.CLOUDFLARE.COM", "bob.ns.cloudflare.com"}}
+	require.True(t, cloudflare.Detect(whois))
+	require.False(t, hetzner.Detect(whois))
+
+	whois = &sprint.Whois{NServer: []string{"hydrogen.ns.hetzner.com"}}
+	require.False(t, hetzner.Detect(whois))
+
+	whois = &sprint.Whois{NServer: []string{"a.ns.example.net"}}
+	require.True(t, hetzner.Detect(whois))
+}
+
+func TestLegoProviderClientFactory(t *testing.T) {
+
+	properties := glue.NewProperties()
+	properties.Set("dns.lego.hetzner.api-key", "secret")
+
+	expected := &struct{ sprint.DNSProviderClient }{}
+	factory := &implLegoProviderFactory{
+		Properties:      properties,
+		ClientFactories: []api.DNSProviderClientFactory{&stubClientFactory{name: "Hetzner", client: expected}},
+	}
+	require.NoError(t, factory.PostConstruct())
+
+	client, err := factory.Providers()[0].NewClient()
+	require.NoError(t, err)
+	require.True(t, client == expected)
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package rfc2136
+
+import (
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/miekg/dns"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client RFC 2136 dynamic update client, records are listed by AXFR.
+type Client struct {
+	Nameserver     string
+	TSIGKey        string
+	TSIGSecret     string
+	TSIGAlgorithm  string
+	TTL            int
+	Timeout        time.Duration
+	PublicIPURL    string  // echo service returning the caller address as text, disabled if empty
+}
+
+// NewClient creates a new Client for the name server in host:port format.
+func NewClient(nameserver string) *Client {
+	return &Client{
+		Nameserver:    nameserver,
+		TSIGAlgorithm: dns.HmacSHA256,
+		TTL:           120,
+		Timeout:       10 * time.Second,
+	}
+}
+
+// GetRecords gets a DNS records of the zone by zone transfer, the record id is the record in presentation format.
+func (c *Client) GetRecords(zoneID string) ([]*sprint.DNSRecord, error) {
+
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zoneID))
+	c.sign(m)
+
+	tr := &dns.Transfer{
+		DialTimeout:  c.Timeout,
+		ReadTimeout:  c.Timeout,
+		WriteTimeout: c.Timeout,
+	}
+	if c.TSIGKey != "" {
+		tr.TsigSecret = map[string]string{dns.Fqdn(c.TSIGKey): c.TSIGSecret}
+	}
+
+	ch, err := tr.In(m, c.Nameserver)
+	if err != nil {
+		return nil, fmt.Errorf("zone transfer failed: %w", err)
+	}
+
+	var records []*sprint.DNSRecord
+	for env := range ch {
+		if env.Error != nil {
+			return nil, fmt.Errorf("zone transfer failed: %w", env.Error)
+		}
+		for _, rr := range env.RR {
+			if rr.Header().Rrtype == dns.TypeSOA {
+				continue
+			}
+			records = append(records, toRecord(rr))
+		}
+	}
+
+	return records, nil
+}
+
+// CreateRecord creates a DNS records, the hostname is either the full name or relative to the zone.
+func (c *Client) CreateRecord(zoneID string, record *sprint.DNSRecord) (*sprint.DNSRecord, error) {
+
+	rr, err := c.toRR(zoneID, record)
+	if err != nil {
+		return nil, err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zoneID))
+	m.Insert([]dns.RR{rr})
+
+	if err := c.exchange(m); err != nil {
+		return nil, err
+	}
+
+	return toRecord(rr), nil
+}
+
+// RemoveRecord removes a DNS records by id returned from GetRecords or CreateRecord.
+func (c *Client) RemoveRecord(zoneID, recordID string) error {
+
+	rr, err := dns.NewRR(recordID)
+	if err != nil {
+		return fmt.Errorf("invalid record id '%s': %w", recordID, err)
+	}
+
+	if rr == nil {
+		return fmt.Errorf("empty record id")
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zoneID))
+	m.Remove([]dns.RR{rr})
+
+	return c.exchange(m)
+}
+
+func (c *Client) GetPublicIP() (addr string, err error) {
+	if c.PublicIPURL == "" {
+		return "", fmt.Errorf("public ip url is not configured")
+	}
+	client := &http.Client{
+		Timeout: c.Timeout,
+	}
+	res, err := client.Get(c.PublicIPURL)
+	if err != nil {
+		return  "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("public ip url '%s' returned status %d", c.PublicIPURL, res.StatusCode)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(res.Body, 256))
+	if err != nil {
+		return "", err
+	}
+	ip := strings.TrimSpace(string(content))
+	if net.ParseIP(ip) == nil {
+		return "", fmt.Errorf("public ip url '%s' returned invalid address '%s'", c.PublicIPURL, ip)
+	}
+	return ip, nil
+}
+
+func (c *Client) exchange(m *dns.Msg) error {
+
+	c.sign(m)
+
+	client := &dns.Client{
+		Net:     "tcp",
+		Timeout: c.Timeout,
+	}
+	if c.TSIGKey != "" {
+		client.TsigSecret = map[string]string{dns.Fqdn(c.TSIGKey): c.TSIGSecret}
+	}
+
+	reply, _, err := client.Exchange(m, c.Nameserver)
+	if err != nil {
+		return fmt.Errorf("DNS update failed: %w", err)
+	}
+
+	if reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("DNS update failed: server replied %s", dns.RcodeToString[reply.Rcode])
+	}
+
+	return nil
+}
+
+func (c *Client) sign(m *dns.Msg) {
+	if c.TSIGKey != "" {
+		m.SetTsig(dns.Fqdn(c.TSIGKey), dns.Fqdn(c.TSIGAlgorithm), 300, time.Now().Unix())
+	}
+}
+
+func (c *Client) toRR(zoneID string, record *sprint.DNSRecord) (dns.RR, error) {
+
+	zone := strings.ToLower(dns.Fqdn(zoneID))
+	name := strings.ToLower(record.Hostname)
+
+	switch {
+	case name == "" || name == "@":
+		name = zone
+	case strings.HasSuffix(dns.Fqdn(name), "."+zone) || dns.Fqdn(name) == zone:
+		name = dns.Fqdn(name)
+	default:
+		name = name + "." + zone
+	}
+
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = c.TTL
+	}
+
+	recordType := strings.ToUpper(record.Type)
+	value := record.Value
+
+	switch recordType {
+	case "TXT":
+		value = fmt.Sprintf("%q", value)
+	case "MX":
+		value = fmt.Sprintf("%d %s", record.Priority, dns.Fqdn(value))
+	case "CNAME", "NS":
+		value = dns.Fqdn(value)
+	}
+
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", name, ttl, recordType, value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid record '%s' of type '%s': %w", record.Hostname, record.Type, err)
+	}
+
+	if rr == nil {
+		return nil, fmt.Errorf("empty record '%s' of type '%s'", record.Hostname, record.Type)
+	}
+
+	return rr, nil
+}
+
+func toRecord(rr dns.RR) *sprint.DNSRecord {
+
+	hdr := rr.Header()
+
+	record := &sprint.DNSRecord{
+		ID:       strings.ReplaceAll(rr.String(), "\t", " "),
+		Hostname: strings.TrimSuffix(hdr.Name, "."),
+		TTL:      int(hdr.Ttl),
+		Type:     dns.TypeToString[hdr.Rrtype],
+		Value:    strings.TrimPrefix(rr.String(), hdr.String()),
+	}
+
+	switch v := rr.(type) {
+	case *dns.A:
+		record.Value = v.A.String()
+	case *dns.AAAA:
+		record.Value = v.AAAA.String()
+	case *dns.CNAME:
+		record.Value = strings.TrimSuffix(v.Target, ".")
+	case *dns.NS:
+		record.Value = strings.TrimSuffix(v.Ns, ".")
+	case *dns.TXT:
+		record.Value = strings.Join(v.Txt, "")
+	case *dns.MX:
+		record.Value = strings.TrimSuffix(v.Mx, ".")
+		record.Priority = int(v.Preference)
+	}
+
+	return record
+}
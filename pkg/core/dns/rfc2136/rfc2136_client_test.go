@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package rfc2136_test
+
+import (
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/core/dns/rfc2136"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testTSIGKey    = "update-key."
+	testTSIGSecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA=="
+)
+
+// stubServer is the minimal authoritative server accepting signed AXFR and UPDATE requests.
+type stubServer struct {
+	sync.Mutex
+	soa      dns.RR
+	records  []dns.RR
+}
+
+func (s *stubServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+
+	m := new(dns.Msg)
+	m.SetReply(req)
+
+	if req.IsTsig() == nil || w.TsigStatus() != nil {
+		m.Rcode = dns.RcodeNotAuth
+		w.WriteMsg(m)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	switch req.Opcode {
+	case dns.OpcodeQuery:
+		if len(req.Question) != 1 || req.Question[0].Qtype != dns.TypeAXFR {
+			m.Rcode = dns.RcodeRefused
+			break
+		}
+		m.Answer = append(append([]dns.RR{s.soa}, s.records...), s.soa)
+	case dns.OpcodeUpdate:
+		for _, rr := range req.Ns {
+			if rr.Header().Class == dns.ClassNONE {
+				s.remove(rr)
+			} else {
+				s.records = append(s.records, dns.Copy(rr))
+			}
+		}
+	default:
+		m.Rcode = dns.RcodeNotImplemented
+	}
+
+	m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, time.Now().Unix())
+	w.WriteMsg(m)
+}
+
+func (s *stubServer) remove(rr dns.RR) {
+	rr = dns.Copy(rr)
+	rr.Header().Class = dns.ClassINET
+	var list []dns.RR
+	for _, r := range s.records {
+		rr.Header().Ttl = r.Header().Ttl
+		if !dns.IsDuplicate(r, rr) {
+			list = append(list, r)
+		}
+	}
+	s.records = list
+}
+
+func startStubServer(t *testing.T) string {
+
+	soa, err := dns.NewRR("example.org. 3600 IN SOA ns.example.org. admin.example.org. 1 7200 3600 1209600 300")
+	require.NoError(t, err)
+
+	a, err := dns.NewRR("www.example.org. 300 IN A 192.0.2.1")
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	srv := &dns.Server{
+		Listener:          ln,
+		Handler:           &stubServer{soa: soa, records: []dns.RR{a}},
+		TsigSecret:        map[string]string{testTSIGKey: testTSIGSecret},
+		NotifyStartedFunc: func() { close(started) },
+		// default accept function rejects the UPDATE opcode
+		MsgAcceptFunc:     func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+
+	go srv.ActivateAndServe()
+	<-started
+
+	t.Cleanup(func() {
+		srv.Shutdown()
+	})
+
+	return ln.Addr().String()
+}
+
+func newTestClient(addr string) *rfc2136.Client {
+	client := rfc2136.NewClient(addr)
+	client.TSIGKey = testTSIGKey
+	client.TSIGSecret = testTSIGSecret
+	client.Timeout = 5 * time.Second
+	return client
+}
+
+func TestRecords(t *testing.T) {
+
+	client := newTestClient(startStubServer(t))
+
+	list, err := client.GetRecords("example.org")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, "www.example.org", list[0].Hostname)
+	require.Equal(t, "A", list[0].Type)
+	require.Equal(t, "192.0.2.1", list[0].Value)
+
+	created, err := client.CreateRecord("example.org", &sprint.DNSRecord{
+		Hostname: "api",
+		Type:     "AAAA",
+		Value:    "2001:db8::1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "api.example.org", created.Hostname)
+	require.Equal(t, 120, created.TTL)
+
+	_, err = client.CreateRecord("example.org", &sprint.DNSRecord{
+		Hostname: "example.org",
+		Type:     "TXT",
+		Value:    "v=spf1 -all",
+	})
+	require.NoError(t, err)
+
+	list, err = client.GetRecords("example.org")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(list))
+	require.Equal(t, "2001:db8::1", list[1].Value)
+	require.Equal(t, "v=spf1 -all", list[2].Value)
+
+	err = client.RemoveRecord("example.org", list[0].ID)
+	require.NoError(t, err)
+
+	err = client.RemoveRecord("example.org", created.ID)
+	require.NoError(t, err)
+
+	list, err = client.GetRecords("example.org")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, "TXT", list[0].Type)
+}
+
+func TestWrongSecret(t *testing.T) {
+
+	client := newTestClient(startStubServer(t))
+	client.TSIGSecret = "d3Jvbmc="
+
+	_, err := client.CreateRecord("example.org", &sprint.DNSRecord{
+		Hostname: "www",
+		Type:     "A",
+		Value:    "192.0.2.2",
+	})
+	require.Error(t, err)
+
+	_, err = client.GetRecords("example.org")
+	require.Error(t, err)
+}
+
+func TestGetPublicIP(t *testing.T) {
+
+	client := rfc2136.NewClient("127.0.0.1:53")
+
+	_, err := client.GetPublicIP()
+	require.Error(t, err)
+
+	body := "203.0.113.7\n"
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer echo.Close()
+
+	client.PublicIPURL = echo.URL
+
+	ip, err := client.GetPublicIP()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip)
+
+	body = "<html>blocked</html>"
+	_, err = client.GetPublicIP()
+	require.Error(t, err)
+}
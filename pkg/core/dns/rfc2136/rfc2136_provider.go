@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package rfc2136
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/providers/dns/rfc2136"
+	"github.com/pkg/errors"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+type implRFC2136Provider struct {
+	Properties   glue.Properties  `inject`
+
+	TTL          int            `value:"rfc2136.ttl,default=120"`
+	Timeout      time.Duration  `value:"rfc2136.timeout,default=10s"`
+	Propagation  time.Duration  `value:"rfc2136.propagation-timeout,default=60s"`
+	PublicIPURL  string         `value:"rfc2136.public-ip-url,default="`
+}
+
+func RFC2136Provider() sprint.DNSProvider {
+	return &implRFC2136Provider{}
+}
+
+func (t *implRFC2136Provider) BeanName() string {
+	return "rfc2136_provider"
+}
+
+/**
+	Private name servers are not visible in whois, detection is enabled by 'rfc2136.nservers' property
+*/
+
+func (t *implRFC2136Provider) Detect(whois *sprint.Whois) bool {
+	list := t.getString("rfc2136.nservers", "RFC2136_NSERVERS")
+	if list == "" {
+		return false
+	}
+	for _, ns := range whois.NServer {
+		ns = strings.ToLower(ns)
+		for _, pattern := range strings.Split(list, ";") {
+			if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" && strings.HasSuffix(ns, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *implRFC2136Provider) RegisterChallenge(legoClient interface{}, token string) error {
+
+	client, ok := legoClient.(*lego.Client)
+	if !ok {
+		return errors.Errorf("expected *lego.Client instance")
+	}
+
+	c, err := t.newClient()
+	if err != nil {
+		return err
+	}
+
+	// zone token overrides the TSIG secret
+	if token != "" {
+		c.TSIGSecret = token
+	}
+
+	conf := rfc2136.NewDefaultConfig()
+	conf.Nameserver = c.Nameserver
+	conf.TSIGKey = c.TSIGKey
+	conf.TSIGSecret = c.TSIGSecret
+	conf.TSIGAlgorithm = c.TSIGAlgorithm
+	conf.TTL = c.TTL
+	conf.DNSTimeout = c.Timeout
+	conf.PropagationTimeout = t.Propagation
+
+	prov, err := rfc2136.NewDNSProviderConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	return client.Challenge.SetDNS01Provider(prov)
+}
+
+func (t *implRFC2136Provider) NewClient() (sprint.DNSProviderClient, error) {
+	return t.newClient()
+}
+
+func (t *implRFC2136Provider) newClient() (*Client, error) {
+
+	nameserver := t.getString("rfc2136.nameserver", "RFC2136_NAMESERVER")
+	if nameserver == "" {
+		return nil, errors.New("rfc2136.nameserver is empty in config and empty system env RFC2136_NAMESERVER")
+	}
+
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(strings.Trim(nameserver, "[]"), "53")
+	}
+
+	c := NewClient(nameserver)
+	c.TSIGKey = t.getString("rfc2136.tsig-key", "RFC2136_TSIG_KEY")
+	c.TSIGSecret = t.getString("rfc2136.tsig-secret", "RFC2136_TSIG_SECRET")
+	if alg := t.getString("rfc2136.tsig-algorithm", "RFC2136_TSIG_ALGORITHM"); alg != "" {
+		c.TSIGAlgorithm = alg
+	}
+	c.TTL = t.TTL
+	c.Timeout = t.Timeout
+	c.PublicIPURL = t.PublicIPURL
+
+	if c.TSIGKey != "" && c.TSIGSecret == "" {
+		return nil, errors.Errorf("rfc2136.tsig-secret is empty for TSIG key '%s'", c.TSIGKey)
+	}
+
+	return c, nil
+}
+
+func (t *implRFC2136Provider) getString(key, env string) string {
+	if value := t.Properties.GetString(key, ""); value != "" {
+		return value
+	}
+	return os.Getenv(env)
+}
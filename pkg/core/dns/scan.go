@@ -16,6 +16,7 @@ package dns
 import (
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprintframework/pkg/core/dns/netlify"
+	"github.com/codeallergy/sprintframework/pkg/core/dns/rfc2136"
 	"github.com/codeallergy/sprint"
 )
 
@@ -33,6 +34,8 @@ func (t *dnsProviderScanner) Beans() []interface{} {
 
 	beans := []interface{}{
 		netlify.NetlifyProvider(),
+		rfc2136.RFC2136Provider(),
+		LegoProviderFactory(),
 		&struct {
 			DNSProviders []sprint.DNSProvider `inject`
 		}{},
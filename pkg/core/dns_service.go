@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type implDNSService struct {
+	Application sprint.Application `inject`
+	Log         *zap.Logger        `inject`
+
+	CertificateRepository sprint.CertificateRepository  `inject`
+	DNSProviders          map[string]sprint.DNSProvider `inject:"optional"`
+	DNSProviderFactories  []api.DNSProviderFactory      `inject:"optional"`
+
+	providerMap   map[string]sprint.DNSProvider // key is the provider name, not bean_name
+	providerList  []string
+}
+
+func DNSService() api.DNSService {
+	return &implDNSService{
+		providerMap: make(map[string]sprint.DNSProvider),
+	}
+}
+
+func (t *implDNSService) BeanName() string {
+	return "dns_service"
+}
+
+func (t *implDNSService) PostConstruct() error {
+
+	t.providerList = indexDNSProviders(t.DNSProviders, t.DNSProviderFactories, t.providerMap)
+	return nil
+}
+
+/**
+	Indexes the provider beans and the providers created by the factories by the provider name, beans take precedence
+*/
+
+func indexDNSProviders(beans map[string]sprint.DNSProvider, factories []api.DNSProviderFactory, providerMap map[string]sprint.DNSProvider) []string {
+
+	add := func(beanName string, prov sprint.DNSProvider) {
+		name := strings.TrimSuffix(beanName, "_provider")
+		if _, ok := providerMap[name]; !ok {
+			providerMap[name] = prov
+		}
+	}
+
+	for beanName, prov := range beans {
+		add(beanName, prov)
+	}
+
+	for _, factory := range factories {
+		for _, prov := range factory.Providers() {
+			add(prov.BeanName(), prov)
+		}
+	}
+
+	var list []string
+	for name := range providerMap {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func (t *implDNSService) ExecuteCommand(cmd string, args []string) (string, error) {
+
+	switch cmd {
+	case "list":
+		return t.listRecords(args)
+
+	case "add":
+		return t.addRecord(args)
+
+	case "remove":
+		return t.removeRecord(args)
+
+	case "providers":
+		return t.listProviders(args)
+
+	default:
+		return "", errors.Errorf("unknown command '%s'", cmd)
+	}
+
+}
+
+func (t *implDNSService) listProviders(args []string) (string, error) {
+
+	var out strings.Builder
+	out.WriteString("Provider,Client\n")
+	for _, name := range t.providerList {
+		status := "ok"
+		if _, err := t.providerMap[name].NewClient(); err != nil {
+			status = err.Error()
+		}
+		out.WriteString(fmt.Sprintf("%s,%s\n", name, status))
+	}
+	return out.String(), nil
+}
+
+func (t *implDNSService) listRecords(args []string) (string, error) {
+
+	args, provider := removeOption(args, "--provider")
+
+	if len(args) < 1 {
+		return fmt.Sprintf("Usage: ./%s dns list zone [--provider name]", t.Application.Name()), nil
+	}
+
+	zone, client, err := t.zoneClient(args[0], provider)
+	if err != nil {
+		return "", err
+	}
+
+	list, err := client.GetRecords(zone)
+	if err != nil {
+		return "", errors.Wrapf(err, "get records of zone '%s'", zone)
+	}
+
+	var out strings.Builder
+	out.WriteString("ID,Hostname,TTL,Type,Priority,Value\n")
+	for _, r := range list {
+		out.WriteString(fmt.Sprintf("%s,%s,%d,%s,%d,%s\n", quoteRecordID(r.ID), r.Hostname, r.TTL, r.Type, r.Priority, r.Value))
+	}
+	return out.String(), nil
+}
+
+func (t *implDNSService) addRecord(args []string) (string, error) {
+
+	args, provider := removeOption(args, "--provider")
+
+	if len(args) < 4 {
+		return fmt.Sprintf("Usage: ./%s dns add zone name type value [ttl] [--provider name]", t.Application.Name()), nil
+	}
+
+	zone, client, err := t.zoneClient(args[0], provider)
+	if err != nil {
+		return "", err
+	}
+
+	record := &sprint.DNSRecord{
+		Hostname: strings.ToLower(args[1]),
+		Type:     strings.ToUpper(args[2]),
+		Value:    args[3],
+	}
+
+	// MX value could be given with the priority, like '10 mail.example.com'
+	if record.Type == "MX" {
+		if parts := strings.Fields(record.Value); len(parts) == 2 {
+			if record.Priority, err = strconv.Atoi(parts[0]); err != nil {
+				return "", errors.Errorf("invalid MX priority '%s'", parts[0])
+			}
+			record.Value = parts[1]
+		}
+	}
+
+	if len(args) > 4 {
+		record.TTL, err = strconv.Atoi(args[4])
+		if err != nil || record.TTL < 0 {
+			return "", errors.Errorf("invalid ttl '%s'", args[4])
+		}
+	}
+
+	created, err := client.CreateRecord(zone, record)
+	if err != nil {
+		return "", errors.Wrapf(err, "create record '%s' of type '%s' in zone '%s'", record.Hostname, record.Type, zone)
+	}
+
+	t.Log.Info("DNSRecordAdd", zap.String("zone", zone), zap.String("id", created.ID), zap.String("hostname", created.Hostname), zap.String("type", created.Type), zap.String("value", created.Value))
+
+	return fmt.Sprintf("Created record %s in zone '%s': %s %d %s %s", quoteRecordID(created.ID), zone, created.Hostname, created.TTL, created.Type, created.Value), nil
+}
+
+func (t *implDNSService) removeRecord(args []string) (string, error) {
+
+	args, provider := removeOption(args, "--provider")
+
+	if len(args) < 2 {
+		return fmt.Sprintf("Usage: ./%s dns remove zone id [--provider name]", t.Application.Name()), nil
+	}
+
+	zone, client, err := t.zoneClient(args[0], provider)
+	if err != nil {
+		return "", err
+	}
+
+	id := args[1]
+
+	if err := client.RemoveRecord(zone, id); err != nil {
+		return "", errors.Wrapf(err, "remove record %s from zone '%s'", quoteRecordID(id), zone)
+	}
+
+	t.Log.Info("DNSRecordRemove", zap.String("zone", zone), zap.String("id", id))
+
+	return fmt.Sprintf("Removed record %s from zone '%s'", quoteRecordID(id), zone), nil
+}
+
+/**
+	Finds the DNS provider client of the zone, the provider is taken from the certificate zone if not given
+*/
+
+func (t *implDNSService) zoneClient(zone, provider string) (string, sprint.DNSProviderClient, error) {
+
+	zone = strings.ToLower(dns01.UnFqdn(zone))
+
+	if provider == "" {
+		entry, err := t.CertificateRepository.FindZone(zone)
+		if err != nil {
+			return "", nil, err
+		}
+		if entry.DnsProvider == "" {
+			return "", nil, errors.Errorf("zone '%s' does not have DNS provider, use --provider option with one of %+v", zone, t.providerList)
+		}
+		provider = entry.DnsProvider
+	}
+
+	prov, ok := t.providerMap[provider]
+	if !ok {
+		return "", nil, errors.Errorf("dns provider '%s' not found in supported list %+v", provider, t.providerList)
+	}
+
+	client, err := prov.NewClient()
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "init dns provider '%s'", provider)
+	}
+
+	return zone, client, nil
+}
+
+func removeOption(args []string, option string) ([]string, string) {
+	for i, arg := range args {
+		if arg == option && i + 1 < len(args) {
+			return append(args[:i:i], args[i+2:]...), strings.ToLower(args[i+1])
+		}
+	}
+	return args, ""
+}
+
+func quoteRecordID(id string) string {
+	if strings.ContainsAny(id, " \t,\"") {
+		return strconv.Quote(id)
+	}
+	return id
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+type stubDNSProvider struct {
+	sprint.DNSProvider
+	name   string
+	client *stubDNSProviderClient
+}
+
+func (t *stubDNSProvider) BeanName() string {
+	return t.name
+}
+
+func (t *stubDNSProvider) NewClient() (sprint.DNSProviderClient, error) {
+	if t.client == nil {
+		return nil, errors.New("no credentials")
+	}
+	return t.client, nil
+}
+
+type stubDNSProviderClient struct {
+	publicIP  string
+	records   map[string][]*sprint.DNSRecord
+	nextID    int
+	createErr error
+	creates   int
+}
+
+func newStubDNSProviderClient() *stubDNSProviderClient {
+	return &stubDNSProviderClient{
+		records: make(map[string][]*sprint.DNSRecord),
+	}
+}
+
+func (t *stubDNSProviderClient) GetPublicIP() (string, error) {
+	if t.publicIP == "" {
+		return "", errors.New("public ip is not available")
+	}
+	return t.publicIP, nil
+}
+
+func (t *stubDNSProviderClient) GetRecords(zone string) ([]*sprint.DNSRecord, error) {
+	return t.records[zone], nil
+}
+
+func (t *stubDNSProviderClient) CreateRecord(zone string, record *sprint.DNSRecord) (*sprint.DNSRecord, error) {
+	t.creates++
+	if t.createErr != nil {
+		return nil, t.createErr
+	}
+	t.nextID++
+	created := *record
+	created.ID = fmt.Sprintf("r%d", t.nextID)
+	t.records[zone] = append(t.records[zone], &created)
+	return &created, nil
+}
+
+func (t *stubDNSProviderClient) RemoveRecord(zone, id string) error {
+	list := t.records[zone]
+	for i, r := range list {
+		if r.ID == id {
+			t.records[zone] = append(list[:i:i], list[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("record '%s' not found", id)
+}
+
+func newTestDNSService(t *testing.T, client *stubDNSProviderClient) *implDNSService {
+	service := DNSService().(*implDNSService)
+	service.Application = &stubApplication{}
+	service.Log = zap.NewNop()
+	service.CertificateRepository = &stubZoneRepository{
+		zones: []*sprintpb.Zone{
+			{Zone: "example.com", DnsProvider: "stub"},
+			{Zone: "example.org"},
+		},
+	}
+	service.DNSProviders = map[string]sprint.DNSProvider{
+		"stub_provider":   &stubDNSProvider{name: "stub_provider", client: client},
+		"broken_provider": &stubDNSProvider{name: "broken_provider"},
+	}
+	require.NoError(t, service.PostConstruct())
+	return service
+}
+
+func TestDNSServiceProviders(t *testing.T) {
+
+	service := newTestDNSService(t, newStubDNSProviderClient())
+
+	content, err := service.ExecuteCommand("providers", nil)
+	require.NoError(t, err)
+	require.Equal(t, "Provider,Client\nbroken,no credentials\nstub,ok\n", content)
+
+	_, err = service.ExecuteCommand("unknown", nil)
+	require.Error(t, err)
+}
+
+func TestDNSServiceAddListRemove(t *testing.T) {
+
+	client := newStubDNSProviderClient()
+	service := newTestDNSService(t, client)
+
+	content, err := service.ExecuteCommand("add", []string{"Example.com.", "WWW", "a", "192.0.2.1", "300"})
+	require.NoError(t, err)
+	require.Equal(t, "Created record r1 in zone 'example.com': www 300 A 192.0.2.1", content)
+
+	_, err = service.ExecuteCommand("add", []string{"example.com", "@", "mx", "10 mail.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, 10, client.records["example.com"][1].Priority)
+	require.Equal(t, "mail.example.com", client.records["example.com"][1].Value)
+
+	_, err = service.ExecuteCommand("add", []string{"example.com", "www", "A", "192.0.2.1", "-1"})
+	require.Error(t, err)
+
+	content, err = service.ExecuteCommand("list", []string{"example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "ID,Hostname,TTL,Type,Priority,Value\nr1,www,300,A,0,192.0.2.1\nr2,@,0,MX,10,mail.example.com\n", content)
+
+	content, err = service.ExecuteCommand("remove", []string{"example.com", "r1"})
+	require.NoError(t, err)
+	require.Equal(t, "Removed record r1 from zone 'example.com'", content)
+	require.Len(t, client.records["example.com"], 1)
+
+	_, err = service.ExecuteCommand("remove", []string{"example.com", "r1"})
+	require.Error(t, err)
+
+	content, err = service.ExecuteCommand("list", nil)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(content, "Usage:"))
+}
+
+func TestDNSServiceZoneProvider(t *testing.T) {
+
+	client := newStubDNSProviderClient()
+	service := newTestDNSService(t, client)
+
+	_, err := service.ExecuteCommand("list", []string{"example.org"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not have DNS provider")
+
+	_, err = service.ExecuteCommand("add", []string{"example.org", "www", "A", "192.0.2.2", "--provider", "STUB"})
+	require.NoError(t, err)
+	require.Len(t, client.records["example.org"], 1)
+
+	_, err = service.ExecuteCommand("list", []string{"example.org", "--provider", "missing"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found in supported list")
+
+	_, err = service.ExecuteCommand("list", []string{"example.org", "--provider", "broken"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "init dns provider 'broken'")
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/store"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	DynDNSBucket    = "dyndns"
+)
+
+func (t *implDynDNSService) startJob() error {
+	t.job = &periodicJob{
+		name:     t.BeanName(),
+		delay:    t.Delay,
+		interval: t.Interval,
+		execute: func(ctx context.Context) error {
+			return t.Sync(true)
+		},
+		run: t.run,
+	}
+	return t.job.start(t.JobService)
+}
+
+func (t *implDynDNSService) Destroy() error {
+	t.job.stop()
+	return nil
+}
+
+func (t *implDynDNSService) run() {
+	if err := t.Sync(false); err != nil {
+		t.Log.Error("DynDNSSync", zap.Error(err))
+	}
+}
+
+func (t *implDynDNSService) GetStats(cb func(name, value string) bool) error {
+	cb("enabled", strconv.FormatBool(t.Enabled))
+	if lastCheck := t.lastCheck.Load(); lastCheck != 0 {
+		cb("lastCheck", time.Unix(lastCheck, 0).String())
+	}
+	if lastChange := t.lastChange.Load(); lastChange != 0 {
+		cb("lastChange", time.Unix(lastChange, 0).String())
+	}
+	if lastErr := t.lastErr.Load(); lastErr != "" {
+		cb("lastErr", lastErr)
+	}
+	if ip := t.syncedIP.Load(); ip != "" {
+		cb("externalIP", ip)
+	}
+	if ip := t.syncedIPv6.Load(); ip != "" {
+		cb("externalIPv6", ip)
+	}
+	cb("checks", strconv.FormatInt(t.checks.Load(), 10))
+	cb("syncs", strconv.FormatInt(t.syncs.Load(), 10))
+	return nil
+}
+
+/**
+	Updates the records of the zones where the external address was changed since the last sync of the zone.
+	If the IPv4 address was not detected, it is resolved by the DNS provider of every zone.
+*/
+
+func (t *implDynDNSService) Sync(force bool) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+		if err != nil {
+			t.lastErr.Store(err.Error())
+		} else {
+			t.lastErr.Store("")
+		}
+	}()
+
+	t.syncMu.Lock()
+	defer t.syncMu.Unlock()
+
+	externalIP, externalIPv6 := t.detectExternalIPs()
+
+	t.checks.Inc()
+	t.lastCheck.Store(time.Now().Unix())
+
+	if !force && externalIP != "" && externalIP == t.syncedIP.Load() && externalIPv6 == t.syncedIPv6.Load() {
+		return nil
+	}
+
+	subDomains := make([]string, len(t.SubDomains))
+	for i, subDomain := range t.SubDomains {
+		if subDomain != "@" {
+			subDomains[i] = subDomain
+		}
+	}
+
+	syncedIP := externalIP
+	var changed bool
+
+	err = t.ensure(externalIP, externalIPv6, func(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string) error {
+
+		syncedIP = externalIP
+
+		previous, err := t.findSyncRecord(zone)
+		if err != nil {
+			return err
+		}
+
+		if !force && previous.Error == "" && previous.ExternalIp == externalIP && previous.ExternalIpv6 == externalIPv6 {
+			return nil
+		}
+
+		t.Log.Info("DynDNSChange",
+			zap.String("zone", zone),
+			zap.String("externalIP", externalIP),
+			zap.String("previousIP", previous.ExternalIp),
+			zap.String("externalIPv6", externalIPv6),
+			zap.String("previousIPv6", previous.ExternalIpv6))
+
+		changed = true
+		err = t.doEnsureAllPublic(client, zone, externalIP, externalIPv6, subDomains)
+
+		record := &pb.DynDNSRecord{
+			Zone:         zone,
+			ExternalIp:   externalIP,
+			ExternalIpv6: externalIPv6,
+			SyncedAt:     time.Now().Unix(),
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+
+		if e := t.saveSyncRecord(record); e != nil {
+			t.Log.Error("DynDNSSave", zap.String("zone", zone), zap.Error(e))
+		}
+
+		return err
+	})
+
+	if changed {
+		t.syncs.Inc()
+	}
+	if err != nil {
+		// retry on the next check
+		return err
+	}
+
+	t.syncedIP.Store(syncedIP)
+	t.syncedIPv6.Store(externalIPv6)
+	if changed {
+		t.lastChange.Store(time.Now().Unix())
+	}
+	return nil
+}
+
+func (t *implDynDNSService) ExecuteCommand(cmd string, args []string) (string, error) {
+
+	switch cmd {
+	case "status":
+		return t.status(args)
+
+	case "sync":
+		if err := t.Sync(true); err != nil {
+			return "", err
+		}
+		return t.status(args)
+
+	default:
+		return "", errors.Errorf("unknown command '%s'", cmd)
+	}
+
+}
+
+func (t *implDynDNSService) status(args []string) (string, error) {
+
+	records, err := t.listSyncRecords()
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Zone < records[j].Zone
+	})
+
+	var out strings.Builder
+	t.GetStats(func(name, value string) bool {
+		out.WriteString(fmt.Sprintf("%s: %s\n", name, value))
+		return true
+	})
+	out.WriteString("\nZone,ExternalIP,ExternalIPv6,Synced,Error\n")
+	for _, r := range records {
+		out.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s\n", r.Zone, r.ExternalIp, r.ExternalIpv6, time.Unix(r.SyncedAt, 0).Format(time.RFC3339), r.Error))
+	}
+	return out.String(), nil
+}
+
+func (t *implDynDNSService) findSyncRecord(zone string) (record *pb.DynDNSRecord, err error) {
+	record = new(pb.DynDNSRecord)
+	err = t.Storage.Get(context.Background()).ByKey("%s:zone:%s", DynDNSBucket, zone).ToProto(record)
+	return
+}
+
+func (t *implDynDNSService) listSyncRecords() ([]*pb.DynDNSRecord, error) {
+	var list []*pb.DynDNSRecord
+	err := t.Storage.Enumerate(context.Background()).ByPrefix("%s:zone:", DynDNSBucket).WithBatchSize(100).DoProto(func() proto.Message {
+		return new(pb.DynDNSRecord)
+	}, func(entry *store.ProtoEntry) bool {
+		if v, ok := entry.Value.(*pb.DynDNSRecord); ok {
+			list = append(list, v)
+		}
+		return true
+	})
+	return list, err
+}
+
+func (t *implDynDNSService) saveSyncRecord(record *pb.DynDNSRecord) error {
+	return t.Storage.Set(context.Background()).ByKey("%s:zone:%s", DynDNSBucket, record.Zone).Proto(record)
+}
@@ -19,10 +19,21 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/codeallergy/sprintpb"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/store"
 	"go.uber.org/zap"
 	"strings"
 	"github.com/pkg/errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"encoding/hex"
+	"sync"
+	"time"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"go.uber.org/atomic"
 )
 
 type implDynDNSService struct {
@@ -31,14 +42,39 @@ type implDynDNSService struct {
 
 	CertificateRepository sprint.CertificateRepository  `inject`
 	DNSProviders          map[string]sprint.DNSProvider `inject`
+	DNSProviderFactories  []api.DNSProviderFactory      `inject:"optional"`
 	NatService            sprint.NatService             `inject`
 
+	JobService            sprint.JobService             `inject`
+	Storage               store.DataStore               `inject:"bean=config-storage"`
+
+	TTL           int     `value:"dyndns.ttl,default=300"`
+	IPv6          bool    `value:"dyndns.ipv6,default=false"`
+	IPv6Url       string  `value:"dyndns.ipv6.url,default=https://api6.ipify.org"`
+	EchoUrl       string  `value:"dyndns.echo.url,default="`
+
+	Enabled       bool           `value:"dyndns.enabled,default=false"`
+	Interval      time.Duration  `value:"dyndns.interval,default=5m"`
+	Delay         time.Duration  `value:"dyndns.delay,default=1m"`
+	SubDomains    []string       `value:"dyndns.subdomains,default=@"`
+
 	providerMap   map[string]sprint.DNSProvider // key is the provider name, not bean_name
 	providerList  []string
 
+	syncMu   sync.Mutex
+
+	job      *periodicJob
+
+	lastCheck     atomic.Int64
+	lastChange    atomic.Int64
+	lastErr       atomic.String
+	syncedIP      atomic.String
+	syncedIPv6    atomic.String
+	syncs         atomic.Int64
+	checks        atomic.Int64
 }
 
-func DynDNSService() sprint.DynDNSService {
+func DynDNSService() api.DynDNSService {
 	return &implDynDNSService{
 		providerMap: make(map[string]sprint.DNSProvider),
 	}
@@ -64,13 +100,10 @@ func (t *implDynDNSService) PostConstruct() (err error) {
 		}
 	}()
 
-	for beanName, prov := range t.DNSProviders {
-		name := beanName
-		if strings.HasSuffix(name, "_provider") {
-			name = name[:len(name) - len("_provider")]
-		}
-		t.providerMap[name] = prov
-		t.providerList = append(t.providerList, name)
+	t.providerList = indexDNSProviders(t.DNSProviders, t.DNSProviderFactories, t.providerMap)
+
+	if t.Enabled {
+		return t.startJob()
 	}
 
 	return nil
@@ -78,14 +111,26 @@ func (t *implDynDNSService) PostConstruct() (err error) {
 
 func (t *implDynDNSService) EnsureAllPublic(subDomains ...string) error {
 
-	return t.EnsureCustom(func(client sprint.DNSProviderClient, zone string, externalIP string) error {
-		return t.doEnsureAllPublic(client, zone, externalIP, subDomains)
+	externalIP, externalIPv6 := t.detectExternalIPs()
+
+	return t.ensure(externalIP, externalIPv6, func(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string) error {
+		return t.doEnsureAllPublic(client, zone, externalIP, externalIPv6, subDomains)
 	})
 
 }
 
 func (t *implDynDNSService) EnsureCustom(cb func(client sprint.DNSProviderClient, zone string, externalIP string) error) error {
 
+	externalIP, _ := t.detectExternalIPs()
+
+	return t.ensure(externalIP, "", func(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string) error {
+		return cb(client, zone, externalIP)
+	})
+
+}
+
+func (t *implDynDNSService) ensure(externalIP, externalIPv6 string, cb func(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string) error) error {
+
 	var list []*sprintpb.Zone
 
 	err := t.CertificateRepository.ListZones("", func(entry *sprintpb.Zone) bool {
@@ -98,19 +143,6 @@ func (t *implDynDNSService) EnsureCustom(cb func(client sprint.DNSProviderClient
 		return err
 	}
 
-	serviceName := t.NatService.ServiceName()
-	t.Log.Info("DynDNS", zap.String("nat", serviceName))
-	
-	var externalIP string
-	if t.NatService.AllowMapping() {
-		extIP, err := t.NatService.ExternalIP()
-		if err != nil {
-			t.Log.Error("NatExternalIP", zap.Error(err))
-		} else {
-			externalIP = extIP.String()
-		}
-	}
-
 	var listErr []error
 
 	for _, entry := range list {
@@ -134,7 +166,7 @@ func (t *implDynDNSService) EnsureCustom(cb func(client sprint.DNSProviderClient
 			}
 		}
 
-		err = cb(client, entry.Zone, externalIP)
+		err = cb(client, entry.Zone, externalIP, externalIPv6)
 		if err != nil {
 			listErr = append(listErr, errors.Wrapf(err, "ensure provider '%s';", entry.DnsProvider))
 		}
@@ -148,7 +180,13 @@ func (t *implDynDNSService) EnsureCustom(cb func(client sprint.DNSProviderClient
 	return nil
 }
 
-func (t *implDynDNSService) doEnsureAllPublic(client sprint.DNSProviderClient, zone string, externalIP string, subDomains []string) error {
+/**
+	Keeps A and AAAA records of the zone and sub-domains pointing to the external addresses.
+	Records with other values are replaced, and missing A (and AAAA if IPv6 is detected) records are created,
+	therefore every listed sub-domain becomes public even if it did not have a record before.
+*/
+
+func (t *implDynDNSService) doEnsureAllPublic(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string, subDomains []string) error {
 
 	zone = dns01.UnFqdn(zone)
 
@@ -157,31 +195,60 @@ func (t *implDynDNSService) doEnsureAllPublic(client sprint.DNSProviderClient, z
 		return err
 	}
 
-	cache := make(map[string]bool)
+	var hosts []string
 	for _, subDomain := range subDomains {
 		if subDomain == "" {
-			cache[zone] = true
+			hosts = append(hosts, zone)
 		} else {
-			cache[fmt.Sprintf("%s.%s", subDomain, zone)] = true
+			hosts = append(hosts, fmt.Sprintf("%s.%s", subDomain, zone))
 		}
 	}
 
+	wanted := map[string]string {
+		"A":    externalIP,
+		"AAAA": externalIPv6,
+	}
+
 	var listErr []error
 
-	for _, record := range list {
-		if record.Type == "A" {
-			host := strings.ToLower(record.Hostname)
-			if cache[host] && record.Value != externalIP {
+	for _, host := range hosts {
 
-				if err = client.RemoveRecord(zone, record.ID); err == nil {
-					record.Value = externalIP
-					record, err = client.CreateRecord(zone, record)
-				}
+		for _, recordType := range []string{"A", "AAAA"} {
+
+			value := wanted[recordType]
+			if value == "" {
+				continue
+			}
 
-				if err != nil {
-					listErr = append(listErr, errors.Wrapf(err, "recreate record for zone '%s' with id '%s' name '%s' and type '%s' value '%s';", zone, record.ID, record.Hostname, record.Type, externalIP))
+			var found bool
+			for _, record := range list {
+				if record.Type != recordType || strings.ToLower(record.Hostname) != host {
+					continue
+				}
+				if record.Value == value {
+					found = true
+					continue
+				}
+				if err := client.RemoveRecord(zone, record.ID); err != nil {
+					listErr = append(listErr, errors.Wrapf(err, "remove record for zone '%s' with id '%s' name '%s' and type '%s' value '%s';", zone, record.ID, record.Hostname, record.Type, record.Value))
 				}
+			}
+
+			if found {
+				continue
+			}
+
+			record := &sprint.DNSRecord{
+				Hostname: host,
+				TTL:      t.TTL,
+				Type:     recordType,
+				Value:    value,
+			}
 
+			if _, err := client.CreateRecord(zone, record); err != nil {
+				listErr = append(listErr, errors.Wrapf(err, "create record for zone '%s' name '%s' and type '%s' value '%s';", zone, record.Hostname, record.Type, record.Value))
+			} else {
+				t.Log.Info("DynDNSRecord", zap.String("zone", zone), zap.String("hostname", host), zap.String("type", recordType), zap.String("value", value))
 			}
 		}
 	}
@@ -191,4 +258,152 @@ func (t *implDynDNSService) doEnsureAllPublic(client sprint.DNSProviderClient, z
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+/**
+	Detects the external addresses by NAT service or by the echo service, the empty IPv4 address is resolved by the DNS provider
+*/
+
+func (t *implDynDNSService) detectExternalIPs() (externalIP string, externalIPv6 string) {
+
+	serviceName := t.NatService.ServiceName()
+	t.Log.Debug("DynDNS", zap.String("nat", serviceName))
+
+	if serviceName != "no_nat" {
+		extIP, err := t.NatService.ExternalIP()
+		if err != nil {
+			t.Log.Error("NatExternalIP", zap.Error(err))
+		} else {
+			externalIP = extIP.String()
+		}
+	}
+
+	if externalIP == "" && t.EchoUrl != "" {
+		ip, err := echoIP(t.EchoUrl)
+		if err != nil {
+			t.Log.Warn("ExternalIP", zap.String("url", t.EchoUrl), zap.Error(err))
+		} else if ip.To4() != nil {
+			externalIP = ip.String()
+		}
+	}
+
+	if t.IPv6 {
+		var err error
+		externalIPv6, err = t.externalIPv6()
+		if err != nil {
+			t.Log.Warn("ExternalIPv6", zap.Error(err))
+		}
+	}
+
+	return
+}
+
+/**
+	Dual-stack hosts are usually not behind NAT for IPv6, therefore the global address of the interface is used first.
+	Temporary (privacy) addresses are skipped, they rotate every few hours and are not reachable for long.
+*/
+
+func (t *implDynDNSService) externalIPv6() (string, error) {
+
+	temporary := temporaryIPv6Addrs()
+
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && isPublicIPv6(ipNet.IP) && !temporary[ipNet.IP.String()] {
+				return ipNet.IP.String(), nil
+			}
+		}
+	}
+
+	if t.IPv6Url == "" {
+		return "", errors.New("global IPv6 address not found")
+	}
+
+	ip, err := echoIP(t.IPv6Url)
+	if err != nil {
+		return "", err
+	}
+
+	if !isPublicIPv6(ip) {
+		return "", errors.Errorf("invalid IPv6 address '%s' from '%s'", ip, t.IPv6Url)
+	}
+
+	// outgoing connections prefer the temporary address, it is not stable enough to be published
+	if temporary[ip.String()] {
+		return "", errors.Errorf("temporary IPv6 address '%s' from '%s'", ip, t.IPv6Url)
+	}
+
+	return ip.String(), nil
+}
+
+func echoIP(url string) (net.IP, error) {
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d from '%s'", resp.StatusCode, url)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(content)))
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address '%s' from '%s'", string(content), url)
+	}
+
+	return ip, nil
+}
+
+func isPublicIPv6(ip net.IP) bool {
+	return ip != nil && ip.To4() == nil && ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+/**
+	Flags of IPv6 addresses are not exposed by the net package, on Linux they are available in /proc/net/if_inet6
+*/
+
+const (
+	ifaFlagTemporary  = 0x01
+	ifaFlagDeprecated = 0x20
+)
+
+func temporaryIPv6Addrs() map[string]bool {
+	content, err := ioutil.ReadFile("/proc/net/if_inet6")
+	if err != nil {
+		return map[string]bool{}
+	}
+	return parseTemporaryIPv6(string(content))
+}
+
+/**
+	Parses lines in format 'address ifindex prefixlen scope flags ifname' and returns temporary and deprecated addresses
+*/
+
+func parseTemporaryIPv6(content string) map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || len(fields[0]) != 32 {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[4], 16, 32)
+		if err != nil || flags & (ifaFlagTemporary | ifaFlagDeprecated) == 0 {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[0])
+		if err != nil {
+			continue
+		}
+		set[net.IP(raw).String()] = true
+	}
+	return set
+}
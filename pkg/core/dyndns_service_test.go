@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"github.com/codeallergy/cachestore"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/stretchr/testify/require"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"net"
+	"testing"
+)
+
+type stubNatService struct {
+	sprint.NatService
+	name string
+	ip   net.IP
+}
+
+func (t *stubNatService) ServiceName() string {
+	return t.name
+}
+
+func (t *stubNatService) ExternalIP() (net.IP, error) {
+	if t.ip == nil {
+		return nil, errors.New("no external ip")
+	}
+	return t.ip, nil
+}
+
+func recordValues(list []*sprint.DNSRecord) map[string]string {
+	values := make(map[string]string)
+	for _, r := range list {
+		values[r.Type + " " + r.Hostname] = r.Value
+	}
+	return values
+}
+
+func TestEnsureAllPublic(t *testing.T) {
+
+	client := newStubDNSProviderClient()
+	client.records["example.com"] = []*sprint.DNSRecord{
+		{ID: "keep", Hostname: "example.com", Type: "A", Value: "192.0.2.1"},
+		{ID: "old", Hostname: "www.example.com", Type: "A", Value: "198.51.100.1"},
+		{ID: "other", Hostname: "www.example.com", Type: "TXT", Value: "198.51.100.1"},
+	}
+
+	service := &implDynDNSService{Log: zap.NewNop(), TTL: 300}
+
+	err := service.doEnsureAllPublic(client, "example.com.", "192.0.2.1", "", []string{"", "www", "api"})
+	require.NoError(t, err)
+
+	list := client.records["example.com"]
+	require.Len(t, list, 4)
+	require.Equal(t, map[string]string{
+		"A example.com":       "192.0.2.1",
+		"A www.example.com":   "192.0.2.1",
+		"A api.example.com":   "192.0.2.1",
+		"TXT www.example.com": "198.51.100.1",
+	}, recordValues(list))
+
+	ids := make(map[string]bool)
+	for _, r := range list {
+		ids[r.ID] = true
+	}
+	require.True(t, ids["keep"])
+	require.False(t, ids["old"])
+	require.True(t, ids["other"])
+
+	// second run keeps everything and adds AAAA records
+	err = service.doEnsureAllPublic(client, "example.com", "192.0.2.1", "2001:db8::1", []string{""})
+	require.NoError(t, err)
+	require.Len(t, client.records["example.com"], 5)
+	require.Equal(t, "2001:db8::1", recordValues(client.records["example.com"])["AAAA example.com"])
+	require.Equal(t, 300, client.records["example.com"][4].TTL)
+}
+
+func TestEnsureCustomPublicIP(t *testing.T) {
+
+	client := newStubDNSProviderClient()
+	client.publicIP = "203.0.113.7"
+
+	service := &implDynDNSService{
+		Log: zap.NewNop(),
+		CertificateRepository: &stubZoneRepository{
+			zones: []*sprintpb.Zone{
+				{Zone: "example.com", DnsProvider: "stub"},
+				{Zone: "example.net"},
+			},
+		},
+		providerMap: map[string]sprint.DNSProvider{
+			"stub": &stubDNSProvider{name: "stub_provider", client: client},
+		},
+	}
+
+	var zones []string
+	err := service.ensure("", "", func(client sprint.DNSProviderClient, zone string, externalIP, externalIPv6 string) error {
+		zones = append(zones, zone + "=" + externalIP)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com=203.0.113.7"}, zones)
+}
+
+func TestParseTemporaryIPv6(t *testing.T) {
+
+	content := "20010db8000000000000000000000001 02 40 00 00     eth0\n" +
+		"20010db80000000011223344556677aa 02 40 00 01     eth0\n" +
+		"20010db80000000011223344556677bb 02 40 00 21     eth0\n" +
+		"fe800000000000000000000000000001 02 40 20 80     eth0\n" +
+		"invalid\n"
+
+	require.Equal(t, map[string]bool{
+		"2001:db8::1122:3344:5566:77aa": true,
+		"2001:db8::1122:3344:5566:77bb": true,
+	}, parseTemporaryIPv6(content))
+}
+
+func newTestDynDNSService(providers map[string]sprint.DNSProvider, zones ...*sprintpb.Zone) *implDynDNSService {
+	return &implDynDNSService{
+		Log:                   zap.NewNop(),
+		CertificateRepository: &stubZoneRepository{zones: zones},
+		NatService:            &stubNatService{name: "no_nat"},
+		Storage:               cachestore.New("test-storage"),
+		TTL:                   300,
+		SubDomains:            []string{"@", "www"},
+		providerMap:           providers,
+	}
+}
+
+func TestDynDNSSyncChange(t *testing.T) {
+
+	client := newStubDNSProviderClient()
+	client.publicIP = "203.0.113.7"
+
+	service := newTestDynDNSService(map[string]sprint.DNSProvider{
+		"stub": &stubDNSProvider{name: "stub_provider", client: client},
+	}, &sprintpb.Zone{Zone: "example.com", DnsProvider: "stub"})
+
+	// public ip is resolved by the provider when not detected
+	require.NoError(t, service.Sync(false))
+	require.Equal(t, map[string]string{
+		"A example.com":     "203.0.113.7",
+		"A www.example.com": "203.0.113.7",
+	}, recordValues(client.records["example.com"]))
+	require.Equal(t, "203.0.113.7", service.syncedIP.Load())
+	require.Equal(t, int64(1), service.syncs.Load())
+	require.NotZero(t, service.lastChange.Load())
+
+	// unchanged address does not touch the records
+	client.records["example.com"] = client.records["example.com"][:1]
+	require.NoError(t, service.Sync(false))
+	require.Len(t, client.records["example.com"], 1)
+	require.Equal(t, int64(1), service.syncs.Load())
+	require.Equal(t, int64(2), service.checks.Load())
+
+	// forced sync restores the records
+	require.NoError(t, service.Sync(true))
+	require.Len(t, client.records["example.com"], 2)
+
+	// changed address replaces the records
+	client.publicIP = "203.0.113.8"
+	require.NoError(t, service.Sync(false))
+	require.Equal(t, map[string]string{
+		"A example.com":     "203.0.113.8",
+		"A www.example.com": "203.0.113.8",
+	}, recordValues(client.records["example.com"]))
+
+	record, err := service.findSyncRecord("example.com")
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.8", record.ExternalIp)
+	require.Empty(t, record.Error)
+
+	// detected address skips the provider lookup when unchanged
+	service.NatService = &stubNatService{name: "extip", ip: net.ParseIP("203.0.113.8")}
+	client.publicIP = ""
+	require.NoError(t, service.Sync(false))
+	require.Equal(t, int64(3), service.syncs.Load())
+}
+
+func TestDynDNSSyncZoneRecords(t *testing.T) {
+
+	good := newStubDNSProviderClient()
+	bad := newStubDNSProviderClient()
+	bad.createErr = errors.New("quota exceeded")
+
+	service := newTestDynDNSService(map[string]sprint.DNSProvider{
+		"good": &stubDNSProvider{name: "good_provider", client: good},
+		"bad":  &stubDNSProvider{name: "bad_provider", client: bad},
+	},
+		&sprintpb.Zone{Zone: "example.com", DnsProvider: "good"},
+		&sprintpb.Zone{Zone: "example.net", DnsProvider: "bad"},
+		&sprintpb.Zone{Zone: "example.org"},
+	)
+	service.NatService = &stubNatService{name: "extip", ip: net.ParseIP("203.0.113.7")}
+
+	require.Error(t, service.Sync(false))
+	require.Equal(t, "", service.syncedIP.Load())
+	require.Equal(t, 2, good.creates)
+	require.Equal(t, 2, bad.creates)
+
+	records, err := service.listSyncRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	byZone := make(map[string]string)
+	for _, r := range records {
+		require.Equal(t, "203.0.113.7", r.ExternalIp)
+		byZone[r.Zone] = r.Error
+	}
+	require.Empty(t, byZone["example.com"])
+	require.Contains(t, byZone["example.net"], "quota exceeded")
+
+	// only the failed zone is retried
+	bad.createErr = nil
+	require.NoError(t, service.Sync(false))
+	require.Equal(t, 2, good.creates)
+	require.Equal(t, 4, bad.creates)
+	require.Equal(t, "203.0.113.7", service.syncedIP.Load())
+
+	content, err := service.ExecuteCommand("status", nil)
+	require.NoError(t, err)
+	require.Contains(t, content, "\nexample.com,203.0.113.7,,")
+	require.Contains(t, content, "\nexample.net,203.0.113.7,,")
+}
@@ -18,9 +18,14 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"strings"
 	"sync"
+	"time"
 )
 
 var ErrJobNotFound = errors.New("job not found")
@@ -28,6 +33,9 @@ var ErrJobNotFound = errors.New("job not found")
 type implJobService struct {
 	Log           *zap.Logger              `inject`
 
+	// the registry depends on the job service
+	MetricsRegistry  api.MetricsRegistry   `inject:"optional,lazy"`
+
 	muJobs  sync.Mutex
 	jobs    []*sprint.JobInfo
 }
@@ -72,6 +80,18 @@ func (t *implJobService) CancelJob(name string) error {
 
 func (t *implJobService) RunJob(ctx context.Context, name string) (err error) {
 
+	start := time.Now()
+	defer func() {
+		if err != ErrJobNotFound && t.MetricsRegistry != nil {
+			t.MetricsRegistry.ObserveJobRun(name, start, err)
+		}
+	}()
+
+	ctx, span := util.StartSpan(ctx, "JobService.RunJob", trace.WithAttributes(attribute.String("job.name", name)))
+	defer func() {
+		util.EndSpan(span, err)
+	}()
+
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -73,7 +73,7 @@ func (t *implLogFactory) Object() (object interface{}, err error) {
 
 			core := zapcore.NewCore(encoder, writerSyncer, zapcore.DebugLevel)
 
-			return zap.New(core, zap.AddCaller()), nil
+			return zap.New(util.TraceCore(core), zap.AddCaller()), nil
 
 		} else {
 
@@ -99,11 +99,11 @@ func (t *implLogFactory) Object() (object interface{}, err error) {
 			cfg.OutputPaths = []string{
 				logFile,
 			}
-			return cfg.Build()
+			return cfg.Build(zap.WrapCore(util.TraceCore))
 		}
 
 	} else {
-		return zap.NewDevelopment()
+		return zap.NewDevelopment(zap.WrapCore(util.TraceCore))
 	}
 
 }
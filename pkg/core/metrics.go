@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"strings"
+	"time"
+)
+
+/**
+Metrics recorded by the core services, owned by the metrics registry bean.
+*/
+
+type coreMetrics struct {
+	jobRunsTotal           *prometheus.CounterVec
+	jobDurationSeconds     *prometheus.HistogramVec
+	storageCommandsTotal   *prometheus.CounterVec
+	storageCommandSeconds  *prometheus.HistogramVec
+}
+
+func newCoreMetrics() *coreMetrics {
+	return &coreMetrics{
+
+		jobRunsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sprint",
+			Subsystem: "job",
+			Name:      "runs_total",
+			Help:      "Total number of job runs by result.",
+		}, []string{"job", "result"}),
+
+		jobDurationSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "sprint",
+			Subsystem: "job",
+			Name:      "duration_seconds",
+			Help:      "Duration of job runs in seconds.",
+			Buckets:   []float64{.01, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+		}, []string{"job"}),
+
+		storageCommandsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sprint",
+			Subsystem: "storage",
+			Name:      "commands_total",
+			Help:      "Total number of storage management commands by result.",
+		}, []string{"storage", "command", "result"}),
+
+		storageCommandSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "sprint",
+			Subsystem: "storage",
+			Name:      "command_duration_seconds",
+			Help:      "Duration of storage management commands in seconds.",
+			Buckets:   []float64{.01, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+		}, []string{"storage", "command"}),
+	}
+}
+
+func (t *coreMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		t.jobRunsTotal,
+		t.jobDurationSeconds,
+		t.storageCommandsTotal,
+		t.storageCommandSeconds,
+	}
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+func (t *coreMetrics) observeJobRun(name string, start time.Time, err error) {
+	t.jobRunsTotal.WithLabelValues(name, resultLabel(err)).Inc()
+	t.jobDurationSeconds.WithLabelValues(name).Observe(time.Since(start).Seconds())
+}
+
+func (t *coreMetrics) observeStorageCommand(storage, cmd string, start time.Time, err error) {
+	switch cmd = strings.ToLower(cmd); cmd {
+	case "compact", "drop", "clean", "dump", "restore":
+	default:
+		cmd = "unknown"
+	}
+	t.storageCommandsTotal.WithLabelValues(storage, cmd, resultLabel(err)).Inc()
+	t.storageCommandSeconds.WithLabelValues(storage, cmd).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/store"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	dto "github.com/prometheus/client_model/go"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+type implMetricsRegistry struct {
+	JobService         sprint.JobService                 `inject`
+	CertificateMonitor api.CertificateMonitor            `inject:"optional"`
+	StorageMap         map[string]store.ManagedDataStore `inject:"optional"`
+
+	Components         []sprint.Component                `inject:"optional,level=-1"`
+	Collectors         []prometheus.Collector            `inject:"optional,level=-1"`
+
+	registry  *prometheus.Registry
+	metrics   *coreMetrics
+}
+
+func MetricsRegistry() api.MetricsRegistry {
+	return &implMetricsRegistry{
+		registry: prometheus.NewRegistry(),
+		metrics:  newCoreMetrics(),
+	}
+}
+
+func (t *implMetricsRegistry) BeanName() string {
+	return "metrics_registry"
+}
+
+func (t *implMetricsRegistry) PostConstruct() error {
+
+	list := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		&componentCollector{components: t.Components},
+		&jobCollector{jobService: t.JobService},
+		&storageCollector{storageMap: t.StorageMap},
+	}
+
+	if t.CertificateMonitor != nil {
+		list = append(list, &certificateCollector{monitor: t.CertificateMonitor})
+	}
+
+	list = append(list, t.metrics.collectors()...)
+	list = append(list, t.Collectors...)
+
+	for _, c := range list {
+		if _, err := t.RegisterOrGet(c); err != nil {
+			return errors.Errorf("register collector %T, %v", c, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *implMetricsRegistry) Register(c prometheus.Collector) error {
+	return t.registry.Register(c)
+}
+
+func (t *implMetricsRegistry) MustRegister(list ...prometheus.Collector) {
+	t.registry.MustRegister(list...)
+}
+
+func (t *implMetricsRegistry) Unregister(c prometheus.Collector) bool {
+	return t.registry.Unregister(c)
+}
+
+func (t *implMetricsRegistry) RegisterOrGet(c prometheus.Collector) (prometheus.Collector, error) {
+	if err := t.registry.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (t *implMetricsRegistry) Gather() ([]*dto.MetricFamily, error) {
+	return t.registry.Gather()
+}
+
+func (t *implMetricsRegistry) ObserveJobRun(name string, start time.Time, err error) {
+	t.metrics.observeJobRun(name, start, err)
+}
+
+func (t *implMetricsRegistry) ObserveStorageCommand(storage, command string, start time.Time, err error) {
+	t.metrics.observeStorageCommand(storage, command, start, err)
+}
+
+/**
+Exports numeric values of the component stats, non numeric values are available only in the status command.
+*/
+
+var componentStatDesc = prometheus.NewDesc(
+	"sprint_component_stat",
+	"Numeric stat value of the component.",
+	[]string{"component", "stat"}, nil)
+
+type componentCollector struct {
+	components []sprint.Component
+}
+
+func (t *componentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- componentStatDesc
+}
+
+func (t *componentCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, component := range t.components {
+		beanName := component.BeanName()
+		visited := make(map[string]bool)
+		component.GetStats(func(name, value string) bool {
+			if visited[name] {
+				return true
+			}
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				visited[name] = true
+				ch <- prometheus.MustNewConstMetric(componentStatDesc, prometheus.GaugeValue, f, beanName, name)
+			}
+			return true
+		})
+	}
+}
+
+var jobsRegisteredDesc = prometheus.NewDesc(
+	"sprint_job_registered",
+	"Number of registered jobs.",
+	nil, nil)
+
+type jobCollector struct {
+	jobService sprint.JobService
+}
+
+func (t *jobCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jobsRegisteredDesc
+}
+
+func (t *jobCollector) Collect(ch chan<- prometheus.Metric) {
+	if list, err := t.jobService.ListJobs(); err == nil {
+		ch <- prometheus.MustNewConstMetric(jobsRegisteredDesc, prometheus.GaugeValue, float64(len(list)))
+	}
+}
+
+var (
+	certificateExpiryDesc = prometheus.NewDesc(
+		"sprint_certificate_expiry_timestamp_seconds",
+		"Expiration time of the zone certificate in unix seconds.",
+		[]string{"zone"}, nil)
+
+	certificateRenewalErrorDesc = prometheus.NewDesc(
+		"sprint_certificate_renewal_error",
+		"Equals 1 if the last renewal of the zone certificate was failed.",
+		[]string{"zone"}, nil)
+)
+
+type certificateCollector struct {
+	monitor api.CertificateMonitor
+}
+
+func (t *certificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- certificateExpiryDesc
+	ch <- certificateRenewalErrorDesc
+}
+
+func (t *certificateCollector) Collect(ch chan<- prometheus.Metric) {
+	list, err := t.monitor.ListExpiring(time.Duration(math.MaxInt64))
+	if err != nil {
+		return
+	}
+	for _, event := range list {
+		ch <- prometheus.MustNewConstMetric(certificateExpiryDesc, prometheus.GaugeValue, float64(event.NotAfter.Unix()), event.Zone)
+		renewalError := 0.0
+		if event.Error != "" {
+			renewalError = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(certificateRenewalErrorDesc, prometheus.GaugeValue, renewalError, event.Zone)
+	}
+}
+
+var storageSizeDesc = prometheus.NewDesc(
+	"sprint_storage_size_bytes",
+	"Size of the storage files in bytes.",
+	[]string{"storage", "kind"}, nil)
+
+type storageCollector struct {
+	storageMap map[string]store.ManagedDataStore
+}
+
+func (t *storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageSizeDesc
+}
+
+func (t *storageCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, s := range t.storageMap {
+		switch db := s.Instance().(type) {
+		case interface{ Size() (int64, int64) }:
+			// badger
+			lsm, vlog := db.Size()
+			ch <- prometheus.MustNewConstMetric(storageSizeDesc, prometheus.GaugeValue, float64(lsm), name, "lsm")
+			ch <- prometheus.MustNewConstMetric(storageSizeDesc, prometheus.GaugeValue, float64(vlog), name, "vlog")
+		case interface{ Path() string }:
+			// bolt
+			if fi, err := os.Stat(db.Path()); err == nil {
+				ch <- prometheus.MustNewConstMetric(storageSizeDesc, prometheus.GaugeValue, float64(fi.Size()), name, "file")
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core_test
+
+import (
+	"context"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type stubComponent struct {
+}
+
+func (t *stubComponent) BeanName() string {
+	return "stub"
+}
+
+func (t *stubComponent) GetStats(cb func(name, value string) bool) error {
+	cb("requests", "42")
+	cb("started", "yesterday")
+	return nil
+}
+
+func TestMetricsRegistry(t *testing.T) {
+
+	ctx, err := glue.New(
+		zap.NewNop(),
+		core.JobService(),
+		core.MetricsRegistry(),
+		&stubComponent{},
+	)
+	require.NoError(t, err)
+	defer ctx.Close()
+
+	list := ctx.Bean(api.MetricsRegistryClass, glue.DefaultLevel)
+	require.Equal(t, 1, len(list))
+	registry := list[0].Object().(api.MetricsRegistry)
+
+	list = ctx.Bean(sprint.JobServiceClass, glue.DefaultLevel)
+	require.Equal(t, 1, len(list))
+	jobService := list[0].Object().(sprint.JobService)
+
+	require.NoError(t, jobService.AddJob(&sprint.JobInfo{
+		Name: "test",
+		ExecutionFn: func(ctx context.Context) error {
+			return nil
+		},
+	}))
+	require.NoError(t, jobService.RunJob(context.Background(), "test"))
+
+	custom := prometheus.NewCounter(prometheus.CounterOpts{Name: "custom_total", Help: "Custom counter."})
+	require.NoError(t, registry.Register(custom))
+
+	existing, err := registry.RegisterOrGet(custom)
+	require.NoError(t, err)
+	require.Equal(t, custom, existing)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	found := make(map[string]bool)
+	for _, family := range families {
+		found[family.GetName()] = true
+		if family.GetName() == "sprint_component_stat" {
+			require.Equal(t, 1, len(family.Metric))
+			require.Equal(t, 42.0, family.Metric[0].GetGauge().GetValue())
+		}
+	}
+
+	require.True(t, found["sprint_component_stat"])
+	require.True(t, found["sprint_job_registered"])
+	require.True(t, found["sprint_job_runs_total"])
+	require.True(t, found["custom_total"])
+	require.True(t, found["go_goroutines"])
+}
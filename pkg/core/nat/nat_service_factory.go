@@ -40,6 +40,7 @@ func NatServiceFactory() glue.FactoryBean {
 //     "upnp"               uses the Universal Plug and Play protocol
 //     "pmp"                uses NAT-PMP with an auto-detected gateway address
 //     "pmp:192.168.0.1"    uses NAT-PMP with the given gateway address
+//     "stun:host:port"     discovers the external IP by the STUN server, default port is 3478
 func (t *implNatServiceFactory) Object() (object interface{}, err error) {
 
 	expr := t.Properties.GetString("application.nat", "")
@@ -63,6 +64,12 @@ func (t *implNatServiceFactory) Object() (object interface{}, err error) {
 			return PMPService(parts[1])
 		}
 		return pmpDiscovery(), nil
+	case "stun":
+		if len(parts) > 1 {
+			return STUNService(parts[1])
+		} else {
+			return nil, errors.Errorf("missing STUN server address in property application.nat='%s'", expr)
+		}
 	default:
 		return nil, errors.Errorf("unknown mechanism %q in property application.nat='%s'", parts[0], expr)
 	}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package nat
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"github.com/codeallergy/sprint"
+	"github.com/pkg/errors"
+	"net"
+	"time"
+)
+
+const (
+	defaultSTUNPort = "3478"
+
+	stunBindingRequest  = 0x0001
+	stunBindingResponse = 0x0101
+	stunBindingError    = 0x0111
+	stunMagicCookie     = 0x2112A442
+	stunHeaderSize      = 20
+
+	stunAttrMappedAddress    = 0x0001
+	stunAttrXorMappedAddress = 0x0020
+
+	stunFamilyIPv4 = 0x01
+	stunFamilyIPv6 = 0x02
+)
+
+var (
+	ErrSTUNNoAddress = errors.New("STUN response does not have mapped address")
+)
+
+// STUN client discovers the external address by binding requests (RFC 5389), port mapping is not supported.
+type implSTUNService struct {
+	server   string
+	timeout  time.Duration
+	retries  int
+}
+
+func STUNService(address string) (sprint.NatService, error) {
+	if address == "" {
+		return nil, errors.New("empty STUN server address")
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultSTUNPort)
+	}
+	return &implSTUNService{server: address, timeout: 2 * time.Second, retries: 3}, nil
+}
+
+func (t *implSTUNService) ServiceName() string {
+	return "stun"
+}
+
+func (t *implSTUNService) AllowMapping() bool {
+	return false
+}
+
+func (t *implSTUNService) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) error {
+	return nil
+}
+
+func (t *implSTUNService) DeleteMapping(protocol string, extport, intport int) error {
+	return nil
+}
+
+func (t *implSTUNService) ExternalIP() (net.IP, error) {
+
+	conn, err := net.Dial("udp", t.server)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dial STUN server '%s'", t.server)
+	}
+	defer conn.Close()
+
+	req, txID, err := stunRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1500)
+	for i := 0; i < t.retries; i++ {
+
+		if _, err = conn.Write(req); err != nil {
+			return nil, errors.Wrapf(err, "send STUN request to '%s'", t.server)
+		}
+
+		conn.SetReadDeadline(time.Now().Add(t.timeout))
+		var n int
+		n, err = conn.Read(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			return nil, errors.Wrapf(err, "read STUN response from '%s'", t.server)
+		}
+
+		ip, err := parseSTUNResponse(buf[:n], txID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "STUN server '%s'", t.server)
+		}
+		return ip, nil
+	}
+
+	return nil, errors.Errorf("STUN server '%s' does not respond, %v", t.server, err)
+}
+
+func stunRequest() ([]byte, []byte, error) {
+	req := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(req[0:], stunBindingRequest)
+	binary.BigEndian.PutUint16(req[2:], 0)
+	binary.BigEndian.PutUint32(req[4:], stunMagicCookie)
+	if _, err := rand.Read(req[8:stunHeaderSize]); err != nil {
+		return nil, nil, err
+	}
+	return req, req[8:stunHeaderSize], nil
+}
+
+func parseSTUNResponse(msg []byte, txID []byte) (net.IP, error) {
+
+	if len(msg) < stunHeaderSize {
+		return nil, errors.Errorf("short response of %d bytes", len(msg))
+	}
+
+	msgType := binary.BigEndian.Uint16(msg[0:])
+	length := int(binary.BigEndian.Uint16(msg[2:]))
+
+	if binary.BigEndian.Uint32(msg[4:]) != stunMagicCookie {
+		return nil, errors.New("invalid magic cookie in response")
+	}
+	if !bytes.Equal(msg[8:stunHeaderSize], txID) {
+		return nil, errors.New("transaction id mismatch in response")
+	}
+	if msgType == stunBindingError {
+		return nil, errors.New("binding error response")
+	}
+	if msgType != stunBindingResponse {
+		return nil, errors.Errorf("unexpected message type 0x%04x", msgType)
+	}
+	if stunHeaderSize + length > len(msg) {
+		return nil, errors.Errorf("truncated response, length %d", length)
+	}
+
+	var mapped net.IP
+	attrs := msg[stunHeaderSize:stunHeaderSize + length]
+	for len(attrs) >= 4 {
+		attrType := binary.BigEndian.Uint16(attrs[0:])
+		attrLen := int(binary.BigEndian.Uint16(attrs[2:]))
+		if 4 + attrLen > len(attrs) {
+			break
+		}
+		value := attrs[4 : 4 + attrLen]
+
+		switch attrType {
+		case stunAttrXorMappedAddress:
+			if ip := stunAddress(value, msg[4:stunHeaderSize]); ip != nil {
+				return ip, nil
+			}
+		case stunAttrMappedAddress:
+			mapped = stunAddress(value, nil)
+		}
+
+		// attributes are padded to 4 bytes
+		next := 4 + (attrLen + 3) &^ 3
+		if next > len(attrs) {
+			break
+		}
+		attrs = attrs[next:]
+	}
+
+	if mapped != nil {
+		return mapped, nil
+	}
+	return nil, ErrSTUNNoAddress
+}
+
+/**
+	Decodes the address attribute value, the xor key is the magic cookie with transaction id for XOR-MAPPED-ADDRESS.
+*/
+
+func stunAddress(value []byte, xor []byte) net.IP {
+
+	if len(value) < 4 {
+		return nil
+	}
+
+	var ip net.IP
+	switch value[1] {
+	case stunFamilyIPv4:
+		if len(value) < 8 {
+			return nil
+		}
+		ip = make(net.IP, net.IPv4len)
+		copy(ip, value[4:8])
+	case stunFamilyIPv6:
+		if len(value) < 20 {
+			return nil
+		}
+		ip = make(net.IP, net.IPv6len)
+		copy(ip, value[4:20])
+	default:
+		return nil
+	}
+
+	if xor != nil {
+		for i := range ip {
+			ip[i] ^= xor[i]
+		}
+	}
+
+	return ip
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package nat_test
+
+import (
+	"encoding/binary"
+	"github.com/codeallergy/sprintframework/pkg/core/nat"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+/**
+	Minimal STUN responder, answers binding requests by XOR-MAPPED-ADDRESS of the sender
+*/
+
+func runSTUNResponder(t *testing.T, mappedIP net.IP) string {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < 20 || binary.BigEndian.Uint16(buf[0:]) != 0x0001 {
+				continue
+			}
+
+			ip := mappedIP.To4()
+			port := addr.(*net.UDPAddr).Port
+
+			resp := make([]byte, 20 + 12)
+			binary.BigEndian.PutUint16(resp[0:], 0x0101)
+			binary.BigEndian.PutUint16(resp[2:], 12)
+			copy(resp[4:20], buf[4:20])
+
+			attr := resp[20:]
+			binary.BigEndian.PutUint16(attr[0:], 0x0020)
+			binary.BigEndian.PutUint16(attr[2:], 8)
+			attr[5] = 0x01
+			binary.BigEndian.PutUint16(attr[6:], uint16(port) ^ 0x2112)
+			for i := 0; i < 4; i++ {
+				attr[8 + i] = ip[i] ^ resp[4 + i]
+			}
+
+			conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestSTUNExternalIP(t *testing.T) {
+
+	expected := net.ParseIP("203.0.113.7")
+	addr := runSTUNResponder(t, expected)
+
+	service, err := nat.STUNService(addr)
+	require.NoError(t, err)
+	require.Equal(t, "stun", service.ServiceName())
+	require.False(t, service.AllowMapping())
+
+	ip, err := service.ExternalIP()
+	require.NoError(t, err)
+	require.True(t, expected.Equal(ip), ip.String())
+}
+
+func TestSTUNDefaultPort(t *testing.T) {
+
+	_, err := nat.STUNService("")
+	require.Error(t, err)
+
+	service, err := nat.STUNService("127.0.0.1")
+	require.NoError(t, err)
+	require.NotNil(t, service)
+}
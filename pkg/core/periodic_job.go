@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"github.com/codeallergy/sprint"
+	"sync"
+	"time"
+)
+
+/**
+	Periodic job runs by the timer after the delayed start and every interval after that,
+	it is also registered in the job service to be executed on demand
+*/
+
+type periodicJob struct {
+	name      string
+	delay     time.Duration
+	interval  time.Duration
+	execute   func(ctx context.Context) error
+	run       func()
+
+	mu        sync.Mutex
+	timer     *time.Timer
+	closed    bool
+}
+
+func (t *periodicJob) start(jobService sprint.JobService) error {
+
+	err := jobService.AddJob(&sprint.JobInfo{
+		Name:        t.name,
+		Schedule:    t.interval.String(),
+		ExecutionFn: t.execute,
+	})
+	if err != nil {
+		return err
+	}
+
+	// delayed start to skip short living command contexts
+	t.schedule(t.delay)
+	return nil
+}
+
+func (t *periodicJob) schedule(after time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.closed {
+		t.timer = time.AfterFunc(after, t.tick)
+	}
+}
+
+func (t *periodicJob) tick() {
+	t.run()
+	t.schedule(t.interval)
+}
+
+func (t *periodicJob) stop() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
@@ -35,19 +35,25 @@ func (t *coreScanner) CoreBeans() []interface{} {
 
 	beans := []interface{}{
 		LogFactory(),
+		TracingService(),
 		NodeService(),
 		ConfigRepository(10000),
 		JobService(),
+		MetricsRegistry(),
 		StorageService(),
 		WhoisService(),
 		dns.DNSProviderScanner(),
 		sealmod.SealService(),
 		CertificateIssueService(),
 		CertificateRepository(),
+		CertificateAuthority(),
 		CertificateService(),
 		CertificateManager(),
+		CertificateMonitor(),
+		CertificateTransparency(),
 		nat.NatServiceFactory(),
 		DynDNSService(),
+		DNSService(),
 		MailService(),
 		&struct {
 			ClientScanners []sprint.ClientScanner `inject`
@@ -21,10 +21,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"github.com/codeallergy/sprintframework/pkg/server"
 	"github.com/codeallergy/sprint"
 	"github.com/codeallergy/sprintframework/pkg/util"
 	"github.com/codeallergy/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"io"
 	"os"
@@ -42,6 +45,8 @@ type implStorageService struct {
 	StorageMap    map[string]store.ManagedDataStore `inject`
 	Log           *zap.Logger                           `inject`
 
+	MetricsRegistry  api.MetricsRegistry                `inject:"optional,lazy"`
+
 	availableStorages []string
 
 	BackupFilePerm   os.FileMode   `value:"application.perm.backup.file,default=-rw-rw-r--"`
@@ -62,7 +67,16 @@ func (t *implStorageService) PostConstruct() error {
 	return nil
 }
 
-func (t *implStorageService) Execute(name, query string, cb func(string) bool) (err error) {
+func (t *implStorageService) Execute(name, query string, cb func(string) bool) error {
+	return t.execute(context.Background(), name, query, cb)
+}
+
+func (t *implStorageService) execute(ctx context.Context, name, query string, cb func(string) bool) (err error) {
+
+	_, span := util.StartSpan(ctx, "StorageService.Execute", trace.WithAttributes(attribute.String("storage.name", name)))
+	defer func() {
+		util.EndSpan(span, err)
+	}()
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -94,6 +108,7 @@ func (t *implStorageService) Execute(name, query string, cb func(string) bool) (
 
 	cmd := query[:cmdEnd]
 	args := strings.TrimSpace(query[cmdEnd:])
+	span.SetAttributes(attribute.String("storage.command", cmd))
 
 	switch cmd {
 	case "help":
@@ -163,6 +178,11 @@ func (t *implStorageService) Console(stream sprint.StorageConsoleStream) error {
 
 	defaultStorage := "config-storage"
 
+	ctx := context.Background()
+	if s, ok := stream.(interface{ Context() context.Context }); ok {
+		ctx = s.Context()
+	}
+
 	for {
 		request, err := stream.Recv()
 		if err != nil {
@@ -189,7 +209,7 @@ func (t *implStorageService) Console(stream sprint.StorageConsoleStream) error {
 
 		} else {
 
-			err = t.Execute(defaultStorage, request.Query, func(content string) bool {
+			err = t.execute(ctx, defaultStorage, request.Query, func(content string) bool {
 
 				rec := &sprintpb.StorageConsoleResponse{
 					Status:  200,
@@ -297,6 +317,17 @@ func (t *implStorageService) ExecuteCommand(cmd string, args []string) (answer s
 		return "", errors.Errorf("storage '%s' is not found", name)
 	}
 
+	_, span := util.StartSpan(context.Background(), "StorageService.ExecuteCommand", trace.WithAttributes(
+		attribute.String("storage.name", name),
+		attribute.String("storage.command", cmd)))
+
+	defer func() {
+		if t.MetricsRegistry != nil {
+			t.MetricsRegistry.ObserveStorageCommand(name, cmd, start, err)
+		}
+		util.EndSpan(span, err)
+	}()
+
 	switch strings.ToLower(cmd) {
 
 	case "compact":
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package core
+
+import (
+	"context"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.uber.org/zap"
+	"os"
+	"strings"
+	"time"
+)
+
+type implTracingService struct {
+	Application   sprint.Application  `inject`
+	Log           *zap.Logger         `inject`
+
+	Exporter      string         `value:"tracing.exporter,default="`
+	Endpoint      string         `value:"tracing.endpoint,default=localhost:4318"`
+	URLPath       string         `value:"tracing.url-path,default=/v1/traces"`
+	Insecure      bool           `value:"tracing.insecure,default=false"`
+	Headers       []string       `value:"tracing.headers,default="`
+	SampleRatio   float64        `value:"tracing.sample-ratio,default=1"`
+	ServiceName   string         `value:"tracing.service-name,default="`
+	FlushTimeout  time.Duration  `value:"tracing.flush-timeout,default=5s"`
+
+	provider  *sdktrace.TracerProvider
+}
+
+func TracingService() api.TracingService {
+	return &implTracingService{}
+}
+
+func (t *implTracingService) BeanName() string {
+	return "tracing_service"
+}
+
+func (t *implTracingService) PostConstruct() error {
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch strings.ToLower(t.Exporter) {
+	case "", "none":
+		return nil
+	case "otlp", "otlphttp":
+		exporter, err = t.newOTLPExporter()
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return errors.Errorf("unknown tracing exporter '%s' in property 'tracing.exporter', expected otlp or stdout", t.Exporter)
+	}
+
+	if err != nil {
+		return errors.Errorf("create tracing exporter '%s', %v", t.Exporter, err)
+	}
+
+	serviceName := t.ServiceName
+	if serviceName == "" {
+		serviceName = t.Application.Name()
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		semconv.ServiceVersionKey.String(t.Application.Version()),
+		semconv.ProcessPIDKey.Int(os.Getpid()))
+
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		t.Log.Warn("Tracing", zap.Error(err))
+	}))
+
+	t.Log.Info("TracingEnabled",
+		zap.String("exporter", t.Exporter),
+		zap.String("service", serviceName),
+		zap.Float64("sampleRatio", t.SampleRatio))
+
+	return nil
+}
+
+func (t *implTracingService) newOTLPExporter() (sdktrace.SpanExporter, error) {
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(t.Endpoint),
+		otlptracehttp.WithURLPath(t.URLPath),
+	}
+
+	if t.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	if len(t.Headers) > 0 {
+		headers := make(map[string]string)
+		for _, header := range t.Headers {
+			if header = strings.TrimSpace(header); header == "" {
+				continue
+			}
+			kv := strings.SplitN(header, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.Errorf("invalid header '%s' in property 'tracing.headers', expected name=value", header)
+			}
+			headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+func (t *implTracingService) Enabled() bool {
+	return t.provider != nil
+}
+
+func (t *implTracingService) ForceFlush(ctx context.Context) error {
+	if t.provider == nil {
+		return nil
+	}
+	return t.provider.ForceFlush(ctx)
+}
+
+func (t *implTracingService) Destroy() error {
+	if t.provider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), t.FlushTimeout)
+	defer cancel()
+	return t.provider.Shutdown(ctx)
+}
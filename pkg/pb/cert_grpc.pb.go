@@ -0,0 +1,430 @@
+// Copyright (c) 2022-2023, Zander Schwid & Co. LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.13.0
+// source: cert.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CertService_SignCSR_FullMethodName     = "/sprintframework.CertService/SignCSR"
+	CertService_ListZones_FullMethodName   = "/sprintframework.CertService/ListZones"
+	CertService_GetZone_FullMethodName     = "/sprintframework.CertService/GetZone"
+	CertService_CreateZone_FullMethodName  = "/sprintframework.CertService/CreateZone"
+	CertService_RenewZone_FullMethodName   = "/sprintframework.CertService/RenewZone"
+	CertService_DeleteZone_FullMethodName  = "/sprintframework.CertService/DeleteZone"
+	CertService_ExportZone_FullMethodName  = "/sprintframework.CertService/ExportZone"
+	CertService_ListIssuers_FullMethodName = "/sprintframework.CertService/ListIssuers"
+)
+
+// CertServiceClient is the client API for CertService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CertServiceClient interface {
+	//
+	// Signs certificate request by the self signer
+	//
+	SignCSR(ctx context.Context, in *SignCSRRequest, opts ...grpc.CallOption) (*SignCSRResponse, error)
+	//
+	// Lists zones with the certificate details
+	//
+	ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error)
+	//
+	// Gets zone with the certificate details
+	//
+	GetZone(ctx context.Context, in *GetZoneRequest, opts ...grpc.CallOption) (*ZoneInfo, error)
+	//
+	// Creates zone and issues certificate
+	//
+	CreateZone(ctx context.Context, in *CreateZoneRequest, opts ...grpc.CallOption) (*ZoneResponse, error)
+	//
+	// Renews certificate of the zone
+	//
+	RenewZone(ctx context.Context, in *RenewZoneRequest, opts ...grpc.CallOption) (*ZoneResponse, error)
+	//
+	// Deletes zone and its certificate
+	//
+	DeleteZone(ctx context.Context, in *DeleteZoneRequest, opts ...grpc.CallOption) (*DeleteZoneResponse, error)
+	//
+	// Exports certificate bundle of the zone including the private key, available only on the control gRPC server
+	//
+	ExportZone(ctx context.Context, in *ExportZoneRequest, opts ...grpc.CallOption) (*ExportZoneResponse, error)
+	//
+	// Lists self signers
+	//
+	ListIssuers(ctx context.Context, in *ListIssuersRequest, opts ...grpc.CallOption) (*ListIssuersResponse, error)
+}
+
+type certServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCertServiceClient(cc grpc.ClientConnInterface) CertServiceClient {
+	return &certServiceClient{cc}
+}
+
+func (c *certServiceClient) SignCSR(ctx context.Context, in *SignCSRRequest, opts ...grpc.CallOption) (*SignCSRResponse, error) {
+	out := new(SignCSRResponse)
+	err := c.cc.Invoke(ctx, CertService_SignCSR_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error) {
+	out := new(ListZonesResponse)
+	err := c.cc.Invoke(ctx, CertService_ListZones_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) GetZone(ctx context.Context, in *GetZoneRequest, opts ...grpc.CallOption) (*ZoneInfo, error) {
+	out := new(ZoneInfo)
+	err := c.cc.Invoke(ctx, CertService_GetZone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) CreateZone(ctx context.Context, in *CreateZoneRequest, opts ...grpc.CallOption) (*ZoneResponse, error) {
+	out := new(ZoneResponse)
+	err := c.cc.Invoke(ctx, CertService_CreateZone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) RenewZone(ctx context.Context, in *RenewZoneRequest, opts ...grpc.CallOption) (*ZoneResponse, error) {
+	out := new(ZoneResponse)
+	err := c.cc.Invoke(ctx, CertService_RenewZone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) DeleteZone(ctx context.Context, in *DeleteZoneRequest, opts ...grpc.CallOption) (*DeleteZoneResponse, error) {
+	out := new(DeleteZoneResponse)
+	err := c.cc.Invoke(ctx, CertService_DeleteZone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) ExportZone(ctx context.Context, in *ExportZoneRequest, opts ...grpc.CallOption) (*ExportZoneResponse, error) {
+	out := new(ExportZoneResponse)
+	err := c.cc.Invoke(ctx, CertService_ExportZone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certServiceClient) ListIssuers(ctx context.Context, in *ListIssuersRequest, opts ...grpc.CallOption) (*ListIssuersResponse, error) {
+	out := new(ListIssuersResponse)
+	err := c.cc.Invoke(ctx, CertService_ListIssuers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CertServiceServer is the server API for CertService service.
+// All implementations must embed UnimplementedCertServiceServer
+// for forward compatibility
+type CertServiceServer interface {
+	//
+	// Signs certificate request by the self signer
+	//
+	SignCSR(context.Context, *SignCSRRequest) (*SignCSRResponse, error)
+	//
+	// Lists zones with the certificate details
+	//
+	ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error)
+	//
+	// Gets zone with the certificate details
+	//
+	GetZone(context.Context, *GetZoneRequest) (*ZoneInfo, error)
+	//
+	// Creates zone and issues certificate
+	//
+	CreateZone(context.Context, *CreateZoneRequest) (*ZoneResponse, error)
+	//
+	// Renews certificate of the zone
+	//
+	RenewZone(context.Context, *RenewZoneRequest) (*ZoneResponse, error)
+	//
+	// Deletes zone and its certificate
+	//
+	DeleteZone(context.Context, *DeleteZoneRequest) (*DeleteZoneResponse, error)
+	//
+	// Exports certificate bundle of the zone including the private key, available only on the control gRPC server
+	//
+	ExportZone(context.Context, *ExportZoneRequest) (*ExportZoneResponse, error)
+	//
+	// Lists self signers
+	//
+	ListIssuers(context.Context, *ListIssuersRequest) (*ListIssuersResponse, error)
+	mustEmbedUnimplementedCertServiceServer()
+}
+
+// UnimplementedCertServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCertServiceServer struct {
+}
+
+func (UnimplementedCertServiceServer) SignCSR(context.Context, *SignCSRRequest) (*SignCSRResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignCSR not implemented")
+}
+func (UnimplementedCertServiceServer) ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListZones not implemented")
+}
+func (UnimplementedCertServiceServer) GetZone(context.Context, *GetZoneRequest) (*ZoneInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetZone not implemented")
+}
+func (UnimplementedCertServiceServer) CreateZone(context.Context, *CreateZoneRequest) (*ZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateZone not implemented")
+}
+func (UnimplementedCertServiceServer) RenewZone(context.Context, *RenewZoneRequest) (*ZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewZone not implemented")
+}
+func (UnimplementedCertServiceServer) DeleteZone(context.Context, *DeleteZoneRequest) (*DeleteZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteZone not implemented")
+}
+func (UnimplementedCertServiceServer) ExportZone(context.Context, *ExportZoneRequest) (*ExportZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportZone not implemented")
+}
+func (UnimplementedCertServiceServer) ListIssuers(context.Context, *ListIssuersRequest) (*ListIssuersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssuers not implemented")
+}
+func (UnimplementedCertServiceServer) mustEmbedUnimplementedCertServiceServer() {}
+
+// UnsafeCertServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CertServiceServer will
+// result in compilation errors.
+type UnsafeCertServiceServer interface {
+	mustEmbedUnimplementedCertServiceServer()
+}
+
+func RegisterCertServiceServer(s grpc.ServiceRegistrar, srv CertServiceServer) {
+	s.RegisterService(&CertService_ServiceDesc, srv)
+}
+
+func _CertService_SignCSR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignCSRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).SignCSR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_SignCSR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).SignCSR(ctx, req.(*SignCSRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_ListZones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListZonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).ListZones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_ListZones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).ListZones(ctx, req.(*ListZonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_GetZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).GetZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_GetZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).GetZone(ctx, req.(*GetZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_CreateZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).CreateZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_CreateZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).CreateZone(ctx, req.(*CreateZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_RenewZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).RenewZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_RenewZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).RenewZone(ctx, req.(*RenewZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_DeleteZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).DeleteZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_DeleteZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).DeleteZone(ctx, req.(*DeleteZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_ExportZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).ExportZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_ExportZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).ExportZone(ctx, req.(*ExportZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertService_ListIssuers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssuersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertServiceServer).ListIssuers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CertService_ListIssuers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertServiceServer).ListIssuers(ctx, req.(*ListIssuersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CertService_ServiceDesc is the grpc.ServiceDesc for CertService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CertService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sprintframework.CertService",
+	HandlerType: (*CertServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignCSR",
+			Handler:    _CertService_SignCSR_Handler,
+		},
+		{
+			MethodName: "ListZones",
+			Handler:    _CertService_ListZones_Handler,
+		},
+		{
+			MethodName: "GetZone",
+			Handler:    _CertService_GetZone_Handler,
+		},
+		{
+			MethodName: "CreateZone",
+			Handler:    _CertService_CreateZone_Handler,
+		},
+		{
+			MethodName: "RenewZone",
+			Handler:    _CertService_RenewZone_Handler,
+		},
+		{
+			MethodName: "DeleteZone",
+			Handler:    _CertService_DeleteZone_Handler,
+		},
+		{
+			MethodName: "ExportZone",
+			Handler:    _CertService_ExportZone_Handler,
+		},
+		{
+			MethodName: "ListIssuers",
+			Handler:    _CertService_ListIssuers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cert.proto",
+}
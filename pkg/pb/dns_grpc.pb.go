@@ -0,0 +1,172 @@
+// Copyright (c) 2022-2023, Zander Schwid & Co. LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.13.0
+// source: dns.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DNSService_DNS_FullMethodName    = "/sprintframework.DNSService/DNS"
+	DNSService_DynDNS_FullMethodName = "/sprintframework.DNSService/DynDNS"
+)
+
+// DNSServiceClient is the client API for DNSService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DNSServiceClient interface {
+	//
+	// Executes dns command: list, add, remove, providers
+	//
+	DNS(ctx context.Context, in *DNSCommand, opts ...grpc.CallOption) (*DNSCommandResult, error)
+	//
+	// Executes dyndns command: status, sync
+	//
+	DynDNS(ctx context.Context, in *DNSCommand, opts ...grpc.CallOption) (*DNSCommandResult, error)
+}
+
+type dNSServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDNSServiceClient(cc grpc.ClientConnInterface) DNSServiceClient {
+	return &dNSServiceClient{cc}
+}
+
+func (c *dNSServiceClient) DNS(ctx context.Context, in *DNSCommand, opts ...grpc.CallOption) (*DNSCommandResult, error) {
+	out := new(DNSCommandResult)
+	err := c.cc.Invoke(ctx, DNSService_DNS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSServiceClient) DynDNS(ctx context.Context, in *DNSCommand, opts ...grpc.CallOption) (*DNSCommandResult, error) {
+	out := new(DNSCommandResult)
+	err := c.cc.Invoke(ctx, DNSService_DynDNS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DNSServiceServer is the server API for DNSService service.
+// All implementations must embed UnimplementedDNSServiceServer
+// for forward compatibility
+type DNSServiceServer interface {
+	//
+	// Executes dns command: list, add, remove, providers
+	//
+	DNS(context.Context, *DNSCommand) (*DNSCommandResult, error)
+	//
+	// Executes dyndns command: status, sync
+	//
+	DynDNS(context.Context, *DNSCommand) (*DNSCommandResult, error)
+	mustEmbedUnimplementedDNSServiceServer()
+}
+
+// UnimplementedDNSServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDNSServiceServer struct {
+}
+
+func (UnimplementedDNSServiceServer) DNS(context.Context, *DNSCommand) (*DNSCommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DNS not implemented")
+}
+func (UnimplementedDNSServiceServer) DynDNS(context.Context, *DNSCommand) (*DNSCommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DynDNS not implemented")
+}
+func (UnimplementedDNSServiceServer) mustEmbedUnimplementedDNSServiceServer() {}
+
+// UnsafeDNSServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DNSServiceServer will
+// result in compilation errors.
+type UnsafeDNSServiceServer interface {
+	mustEmbedUnimplementedDNSServiceServer()
+}
+
+func RegisterDNSServiceServer(s grpc.ServiceRegistrar, srv DNSServiceServer) {
+	s.RegisterService(&DNSService_ServiceDesc, srv)
+}
+
+func _DNSService_DNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DNSCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSServiceServer).DNS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSService_DNS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSServiceServer).DNS(ctx, req.(*DNSCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSService_DynDNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DNSCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSServiceServer).DynDNS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSService_DynDNS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSServiceServer).DynDNS(ctx, req.(*DNSCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DNSService_ServiceDesc is the grpc.ServiceDesc for DNSService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DNSService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sprintframework.DNSService",
+	HandlerType: (*DNSServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DNS",
+			Handler:    _DNSService_DNS_Handler,
+		},
+		{
+			MethodName: "DynDNS",
+			Handler:    _DNSService_DynDNS_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dns.proto",
+}
@@ -1,9 +1,12 @@
 // Package resources Code generated by go-bindata. (@generated) DO NOT EDIT.
 // sources:
 // resources/licenses.txt
+// resources/openapi/cert.swagger.json
 // resources/openapi/certificates.swagger.json
 // resources/openapi/control.swagger.json
+// resources/openapi/dns.swagger.json
 // resources/sprint.yml
+// resources/templates/cert_expiring.tmpl
 // resources/templates/index.tmpl
 package resources
 
@@ -180,6 +183,26 @@ func licensesTxt() (*asset, error) {
 	return a, nil
 }
 
+var _openapiCertSwaggerJson = "\x7b\x0a\x20\x20\x22\x73\x77\x61\x67\x67\x65\x72\x22\x3a\x20\x22\x32\x2e\x30\x22\x2c\x0a\x20\x20\x22\x69\x6e\x66\x6f\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x2c\x0a\x20\x20\x20\x20\x22\x76\x65\x72\x73\x69\x6f\x6e\x22\x3a\x20\x22\x31\x2e\x30\x22\x2c\x0a\x20\x20\x20\x20\x22\x63\x6f\x6e\x74\x61\x63\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x75\x72\x6c\x22\x3a\x20\x22\x68\x74\x74\x70\x73\x3a\x2f\x2f\x67\x69\x74\x68\x75\x62\x2e\x63\x6f\x6d\x2f\x63\x6f\x64\x65\x61\x6c\x6c\x65\x72\x67\x79\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x65\x6d\x61\x69\x6c\x22\x3a\x20\x22\x7a\x61\x6e\x64\x65\x72\x40\x73\x63\x68\x77\x69\x64\x2e\x63\x6f\x6d\x22\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x7d\x2c\x0a\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x73\x63\x68\x65\x6d\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x68\x74\x74\x70\x22\x2c\x0a\x20\x20\x20\x20\x22\x68\x74\x74\x70\x73\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x63\x6f\x6e\x73\x75\x6d\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x72\x6f\x64\x75\x63\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x61\x74\x68\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x2f\x61\x70\x69\x2f\x76\x31\x2f\x63\x65\x72\x74\x2f\x69\x73\x73\x75\x65\x72\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x67\x65\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x4c\x69\x73\x74\x73\x20\x73\x65\x6c\x66\x20\x73\x69\x67\x6e\x65\x72\x73\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x4c\x69\x73\x74\x49\x73\x73\x75\x65\x72\x73\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x4c\x69\x73\x74\x49\x73\x73\x75\x65\x72\x73\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x70\x72\x65\x66\x69\x78\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x71\x75\x65\x72\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x66\x61\x6c\x73\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x2f\x61\x70\x69\x2f\x76\x31\x2f\x63\x65\x72\x74\x2f\x73\x69\x67\x6e\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x6f\x73\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x53\x69\x67\x6e\x73\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x72\x65\x71\x75\x65\x73\x74\x20\x62\x79\x20\x74\x68\x65\x20\x73\x65\x6c\x66\x20\x73\x69\x67\x6e\x65\x72\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x53\x69\x67\x6e\x43\x53\x52\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x53\x69\x67\x6e\x43\x53\x52\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x62\x6f\x64\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x62\x6f\x64\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x74\x72\x75\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x53\x69\x67\x6e\x43\x53\x52\x52\x65\x71\x75\x65\x73\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x2f\x61\x70\x69\x2f\x76\x31\x2f\x63\x65\x72\x74\x2f\x7a\x6f\x6e\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x67\x65\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x4c\x69\x73\x74\x73\x20\x7a\x6f\x6e\x65\x73\x20\x77\x69\x74\x68\x20\x74\x68\x65\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x64\x65\x74\x61\x69\x6c\x73\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x4c\x69\x73\x74\x5a\x6f\x6e\x65\x73\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x4c\x69\x73\x74\x5a\x6f\x6e\x65\x73\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x70\x72\x65\x66\x69\x78\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x71\x75\x65\x72\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x66\x61\x6c\x73\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x6f\x73\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x43\x72\x65\x61\x74\x65\x73\x20\x7a\x6f\x6e\x65\x20\x61\x6e\x64\x20\x69\x73\x73\x75\x65\x73\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x43\x72\x65\x61\x74\x65\x5a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x62\x6f\x64\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x62\x6f\x64\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x74\x72\x75\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x43\x72\x65\x61\x74\x65\x5a\x6f\x6e\x65\x52\x65\x71\x75\x65\x73\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x2f\x61\x70\x69\x2f\x76\x31\x2f\x63\x65\x72\x74\x2f\x7a\x6f\x6e\x65\x73\x2f\x7b\x7a\x6f\x6e\x65\x7d\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x67\x65\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x47\x65\x74\x73\x20\x7a\x6f\x6e\x65\x20\x77\x69\x74\x68\x20\x74\x68\x65\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x64\x65\x74\x61\x69\x6c\x73\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x47\x65\x74\x5a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x49\x6e\x66\x6f\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x7a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x70\x61\x74\x68\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x74\x72\x75\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x77\x69\x74\x68\x43\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x69\x6e\x63\x6c\x75\x64\x65\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x63\x68\x61\x69\x6e\x2c\x20\x6e\x65\x76\x65\x72\x20\x74\x68\x65\x20\x70\x72\x69\x76\x61\x74\x65\x20\x6b\x65\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x71\x75\x65\x72\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x66\x61\x6c\x73\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x62\x6f\x6f\x6c\x65\x61\x6e\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x64\x65\x6c\x65\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x44\x65\x6c\x65\x74\x65\x73\x20\x7a\x6f\x6e\x65\x20\x61\x6e\x64\x20\x69\x74\x73\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x44\x65\x6c\x65\x74\x65\x5a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x44\x65\x6c\x65\x74\x65\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x7a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x70\x61\x74\x68\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x74\x72\x75\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x2f\x61\x70\x69\x2f\x76\x31\x2f\x63\x65\x72\x74\x2f\x7a\x6f\x6e\x65\x73\x2f\x7b\x7a\x6f\x6e\x65\x7d\x2f\x72\x65\x6e\x65\x77\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x6f\x73\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x6d\x6d\x61\x72\x79\x22\x3a\x20\x22\x52\x65\x6e\x65\x77\x73\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x6f\x66\x20\x74\x68\x65\x20\x7a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x49\x64\x22\x3a\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x5f\x52\x65\x6e\x65\x77\x5a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x73\x70\x6f\x6e\x73\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x32\x30\x30\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x20\x73\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x66\x61\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x22\x3a\x20\x22\x41\x6e\x20\x75\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x65\x72\x72\x6f\x72\x20\x72\x65\x73\x70\x6f\x6e\x73\x65\x2e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x63\x68\x65\x6d\x61\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x7a\x6f\x6e\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x6e\x22\x3a\x20\x22\x70\x61\x74\x68\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x71\x75\x69\x72\x65\x64\x22\x3a\x20\x74\x72\x75\x65\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x43\x65\x72\x74\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x5d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x7d\x2c\x0a\x20\x20\x22\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x40\x74\x79\x70\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x50\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x6f\x64\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x69\x6e\x74\x65\x67\x65\x72\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x33\x32\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6d\x65\x73\x73\x61\x67\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x74\x61\x69\x6c\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x43\x72\x65\x61\x74\x65\x5a\x6f\x6e\x65\x52\x65\x71\x75\x65\x73\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x50\x72\x6f\x76\x69\x64\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x6c\x66\x2c\x20\x61\x63\x6d\x65\x2c\x20\x63\x75\x73\x74\x6f\x6d\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x6f\x6d\x61\x69\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x69\x6e\x67\x6c\x65\x20\x64\x6f\x6d\x61\x69\x6e\x20\x67\x65\x74\x73\x20\x74\x68\x65\x20\x7a\x6f\x6e\x65\x20\x61\x6e\x64\x20\x77\x69\x6c\x64\x63\x61\x72\x64\x20\x75\x6e\x6c\x65\x73\x73\x20\x65\x78\x61\x63\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x65\x78\x61\x63\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x62\x6f\x6f\x6c\x65\x61\x6e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x73\x65\x20\x64\x6f\x6d\x61\x69\x6e\x73\x20\x61\x73\x20\x67\x69\x76\x65\x6e\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x65\x6c\x66\x53\x69\x67\x6e\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x73\x65\x6c\x66\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x2c\x20\x6c\x6f\x63\x61\x6c\x68\x6f\x73\x74\x20\x62\x79\x20\x64\x65\x66\x61\x75\x6c\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x61\x63\x6d\x65\x45\x6d\x61\x69\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x61\x63\x6d\x65\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x6e\x73\x50\x72\x6f\x76\x69\x64\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x61\x63\x6d\x65\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x2c\x20\x64\x65\x74\x65\x63\x74\x65\x64\x20\x62\x79\x20\x77\x68\x6f\x69\x73\x20\x69\x66\x20\x65\x6d\x70\x74\x79\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x63\x75\x73\x74\x6f\x6d\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x2c\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x72\x69\x76\x61\x74\x65\x4b\x65\x79\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x63\x75\x73\x74\x6f\x6d\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x2c\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x73\x73\x75\x65\x72\x43\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x63\x75\x73\x74\x6f\x6d\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x2c\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x44\x65\x6c\x65\x74\x65\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x7a\x6f\x6e\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x49\x6e\x66\x6f\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x64\x65\x6c\x65\x74\x65\x64\x20\x7a\x6f\x6e\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x45\x78\x70\x6f\x72\x74\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x6f\x6e\x74\x65\x6e\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x65\x78\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x66\x69\x6c\x65\x20\x65\x78\x74\x65\x6e\x73\x69\x6f\x6e\x20\x6f\x66\x20\x74\x68\x65\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x49\x73\x73\x75\x65\x72\x49\x6e\x66\x6f\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x68\x61\x69\x6e\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x75\x62\x6a\x65\x63\x74\x73\x20\x66\x72\x6f\x6d\x20\x74\x68\x65\x20\x73\x69\x67\x6e\x65\x72\x20\x74\x6f\x20\x74\x68\x65\x20\x72\x6f\x6f\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x65\x72\x69\x61\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x72\x69\x61\x6c\x20\x6e\x75\x6d\x62\x65\x72\x20\x6f\x66\x20\x74\x68\x65\x20\x73\x69\x67\x6e\x65\x72\x20\x69\x6e\x20\x68\x65\x78\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x6f\x74\x42\x65\x66\x6f\x72\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x6f\x74\x41\x66\x74\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x2c\x20\x74\x68\x65\x20\x65\x61\x72\x6c\x69\x65\x73\x74\x20\x69\x6e\x20\x74\x68\x65\x20\x63\x68\x61\x69\x6e\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x63\x68\x61\x69\x6e\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x4c\x69\x73\x74\x49\x73\x73\x75\x65\x72\x73\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x73\x73\x75\x65\x72\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x49\x73\x73\x75\x65\x72\x49\x6e\x66\x6f\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x4c\x69\x73\x74\x5a\x6f\x6e\x65\x73\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x7a\x6f\x6e\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x49\x6e\x66\x6f\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x53\x69\x67\x6e\x43\x53\x52\x52\x65\x71\x75\x65\x73\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x69\x67\x6e\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x6c\x66\x20\x73\x69\x67\x6e\x65\x72\x20\x6e\x61\x6d\x65\x2c\x20\x6c\x6f\x63\x61\x6c\x68\x6f\x73\x74\x20\x62\x79\x20\x64\x65\x66\x61\x75\x6c\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x73\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x72\x65\x71\x75\x65\x73\x74\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x61\x79\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x69\x6e\x74\x65\x67\x65\x72\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x33\x32\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x76\x61\x6c\x69\x64\x69\x74\x79\x20\x70\x65\x72\x69\x6f\x64\x20\x69\x6e\x20\x64\x61\x79\x73\x2c\x20\x7a\x65\x72\x6f\x20\x6d\x65\x61\x6e\x73\x20\x70\x72\x6f\x66\x69\x6c\x65\x20\x64\x65\x66\x61\x75\x6c\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x66\x69\x6c\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x72\x76\x65\x72\x20\x6f\x72\x20\x63\x6c\x69\x65\x6e\x74\x2c\x20\x73\x65\x72\x76\x65\x72\x20\x62\x79\x20\x64\x65\x66\x61\x75\x6c\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x53\x69\x67\x6e\x43\x53\x52\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x73\x73\x75\x65\x72\x43\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x69\x73\x73\x75\x65\x72\x20\x63\x68\x61\x69\x6e\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x65\x72\x69\x61\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x72\x69\x61\x6c\x20\x6e\x75\x6d\x62\x65\x72\x20\x69\x6e\x20\x68\x65\x78\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x6f\x74\x41\x66\x74\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x49\x6e\x66\x6f\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x7a\x6f\x6e\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x6f\x6d\x61\x69\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x72\x65\x71\x75\x65\x73\x74\x65\x64\x20\x64\x6f\x6d\x61\x69\x6e\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6f\x70\x74\x69\x6f\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x50\x72\x6f\x76\x69\x64\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x6c\x66\x2c\x20\x61\x63\x6d\x65\x2c\x20\x63\x75\x73\x74\x6f\x6d\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x6e\x73\x50\x72\x6f\x76\x69\x64\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x61\x63\x6d\x65\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x65\x6c\x66\x53\x69\x67\x6e\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x73\x65\x6c\x66\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x61\x63\x6d\x65\x45\x6d\x61\x69\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6f\x6e\x6c\x79\x20\x66\x6f\x72\x20\x61\x63\x6d\x65\x20\x63\x65\x72\x74\x5f\x70\x72\x6f\x76\x69\x64\x65\x72\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x73\x73\x75\x65\x64\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x62\x6f\x6f\x6c\x65\x61\x6e\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x68\x61\x73\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x75\x62\x6a\x65\x63\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x73\x73\x75\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x73\x65\x72\x69\x61\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x73\x65\x72\x69\x61\x6c\x20\x6e\x75\x6d\x62\x65\x72\x20\x69\x6e\x20\x68\x65\x78\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x6e\x73\x4e\x61\x6d\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x64\x6f\x6d\x61\x69\x6e\x73\x20\x69\x6e\x20\x74\x68\x65\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x6f\x74\x42\x65\x66\x6f\x72\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x6f\x74\x41\x66\x74\x65\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6e\x65\x78\x74\x52\x65\x6e\x65\x77\x61\x6c\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x36\x34\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x75\x6e\x69\x78\x20\x74\x69\x6d\x65\x20\x69\x6e\x20\x73\x65\x63\x6f\x6e\x64\x73\x2c\x20\x7a\x65\x72\x6f\x20\x69\x66\x20\x6e\x6f\x74\x20\x73\x63\x68\x65\x64\x75\x6c\x65\x64\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x72\x65\x6e\x65\x77\x61\x6c\x45\x72\x72\x6f\x72\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x6c\x61\x73\x74\x20\x72\x65\x6e\x65\x77\x61\x6c\x20\x65\x72\x72\x6f\x72\x20\x69\x66\x20\x61\x6e\x79\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x62\x79\x74\x65\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x63\x68\x61\x69\x6e\x20\x69\x6e\x20\x50\x45\x4d\x20\x66\x6f\x72\x6d\x61\x74\x2c\x20\x6f\x6e\x6c\x79\x20\x69\x66\x20\x72\x65\x71\x75\x65\x73\x74\x65\x64\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x52\x65\x73\x70\x6f\x6e\x73\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x7a\x6f\x6e\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x5a\x6f\x6e\x65\x49\x6e\x66\x6f\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6d\x65\x73\x73\x61\x67\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x69\x73\x73\x75\x65\x72\x20\x6c\x6f\x67\x20\x6f\x72\x20\x77\x61\x72\x6e\x69\x6e\x67\x73\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x7d\x0a\x7d\x0a"
+
+func openapiCertSwaggerJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_openapiCertSwaggerJson,
+		"openapi/cert.swagger.json",
+	)
+}
+
+func openapiCertSwaggerJson() (*asset, error) {
+	bytes, err := openapiCertSwaggerJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "openapi/cert.swagger.json", size: 13870, mode: os.FileMode(420), modTime: time.Unix(1792390847, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _openapiCertificatesSwaggerJson = "\x7b\x0a\x20\x20\x22\x73\x77\x61\x67\x67\x65\x72\x22\x3a\x20\x22\x32\x2e\x30\x22\x2c\x0a\x20\x20\x22\x69\x6e\x66\x6f\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x73\x2e\x70\x72\x6f\x74\x6f\x22\x2c\x0a\x20\x20\x20\x20\x22\x76\x65\x72\x73\x69\x6f\x6e\x22\x3a\x20\x22\x76\x65\x72\x73\x69\x6f\x6e\x20\x6e\x6f\x74\x20\x73\x65\x74\x22\x0a\x20\x20\x7d\x2c\x0a\x20\x20\x22\x63\x6f\x6e\x73\x75\x6d\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x72\x6f\x64\x75\x63\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x61\x74\x68\x73\x22\x3a\x20\x7b\x7d\x2c\x0a\x20\x20\x22\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x40\x74\x79\x70\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x50\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x6f\x64\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x69\x6e\x74\x65\x67\x65\x72\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x33\x32\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6d\x65\x73\x73\x61\x67\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x74\x61\x69\x6c\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x7d\x0a\x7d\x0a"
 
 func openapiCertificatesSwaggerJsonBytes() ([]byte, error) {
@@ -220,6 +243,26 @@ func openapiControlSwaggerJson() (*asset, error) {
 	return a, nil
 }
 
+var _openapiDnsSwaggerJson = "\x7b\x0a\x20\x20\x22\x73\x77\x61\x67\x67\x65\x72\x22\x3a\x20\x22\x32\x2e\x30\x22\x2c\x0a\x20\x20\x22\x69\x6e\x66\x6f\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x74\x69\x74\x6c\x65\x22\x3a\x20\x22\x64\x6e\x73\x2e\x70\x72\x6f\x74\x6f\x22\x2c\x0a\x20\x20\x20\x20\x22\x76\x65\x72\x73\x69\x6f\x6e\x22\x3a\x20\x22\x76\x65\x72\x73\x69\x6f\x6e\x20\x6e\x6f\x74\x20\x73\x65\x74\x22\x0a\x20\x20\x7d\x2c\x0a\x20\x20\x22\x74\x61\x67\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x6e\x61\x6d\x65\x22\x3a\x20\x22\x44\x4e\x53\x53\x65\x72\x76\x69\x63\x65\x22\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x63\x6f\x6e\x73\x75\x6d\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x72\x6f\x64\x75\x63\x65\x73\x22\x3a\x20\x5b\x0a\x20\x20\x20\x20\x22\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x2f\x6a\x73\x6f\x6e\x22\x0a\x20\x20\x5d\x2c\x0a\x20\x20\x22\x70\x61\x74\x68\x73\x22\x3a\x20\x7b\x7d\x2c\x0a\x20\x20\x22\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x22\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x40\x74\x79\x70\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x50\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x72\x70\x63\x53\x74\x61\x74\x75\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x6f\x64\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x69\x6e\x74\x65\x67\x65\x72\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x66\x6f\x72\x6d\x61\x74\x22\x3a\x20\x22\x69\x6e\x74\x33\x32\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x6d\x65\x73\x73\x61\x67\x65\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x64\x65\x74\x61\x69\x6c\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x61\x72\x72\x61\x79\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x69\x74\x65\x6d\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x24\x72\x65\x66\x22\x3a\x20\x22\x23\x2f\x64\x65\x66\x69\x6e\x69\x74\x69\x6f\x6e\x73\x2f\x70\x72\x6f\x74\x6f\x62\x75\x66\x41\x6e\x79\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x2c\x0a\x20\x20\x20\x20\x22\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x44\x4e\x53\x43\x6f\x6d\x6d\x61\x6e\x64\x52\x65\x73\x75\x6c\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x6f\x62\x6a\x65\x63\x74\x22\x2c\x0a\x20\x20\x20\x20\x20\x20\x22\x70\x72\x6f\x70\x65\x72\x74\x69\x65\x73\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x22\x63\x6f\x6e\x74\x65\x6e\x74\x22\x3a\x20\x7b\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x22\x74\x79\x70\x65\x22\x3a\x20\x22\x73\x74\x72\x69\x6e\x67\x22\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x20\x20\x7d\x0a\x20\x20\x20\x20\x7d\x0a\x20\x20\x7d\x0a\x7d\x0a"
+
+func openapiDnsSwaggerJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_openapiDnsSwaggerJson,
+		"openapi/dns.swagger.json",
+	)
+}
+
+func openapiDnsSwaggerJson() (*asset, error) {
+	bytes, err := openapiDnsSwaggerJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "openapi/dns.swagger.json", size: 1007, mode: os.FileMode(420), modTime: time.Unix(1792392508, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _sprintYml = "\x61\x70\x70\x6c\x69\x63\x61\x74\x69\x6f\x6e\x3a\x0a\x20\x20\x70\x61\x63\x6b\x61\x67\x65\x3a\x20\x22\x67\x69\x74\x68\x75\x62\x2e\x63\x6f\x6d\x2f\x63\x6f\x64\x65\x61\x6c\x6c\x65\x72\x67\x79\x2f\x73\x70\x72\x69\x6e\x74\x66\x72\x61\x6d\x65\x77\x6f\x72\x6b\x22\x0a\x20\x20\x63\x6f\x6d\x70\x61\x6e\x79\x3a\x20\x22\x43\x6f\x64\x65\x41\x6c\x6c\x65\x72\x67\x79\x22\x0a\x20\x20\x63\x6f\x70\x79\x72\x69\x67\x68\x74\x3a\x20\x22\x43\x6f\x70\x79\x72\x69\x67\x68\x74\x20\x28\x63\x29\x20\x32\x30\x32\x32\x20\x5a\x61\x6e\x64\x65\x72\x20\x53\x63\x68\x77\x69\x64\x20\x26\x20\x43\x6f\x2e\x20\x4c\x4c\x43\x2e\x20\x41\x6c\x6c\x20\x72\x69\x67\x68\x74\x73\x20\x72\x65\x73\x65\x72\x76\x65\x64\x2e\x22\x0a\x20\x20\x6e\x61\x74\x3a\x20\x22\x6e\x6f\x22\x0a\x20\x20\x62\x6f\x6f\x74\x73\x74\x72\x61\x70\x2d\x74\x6f\x6b\x65\x6e\x73\x3a\x20\x22\x62\x6f\x6f\x74\x22\x0a\x0a\x73\x65\x63\x75\x72\x65\x2d\x73\x74\x6f\x72\x61\x67\x65\x3a\x0a\x20\x20\x73\x70\x6c\x69\x74\x2d\x6b\x65\x79\x2d\x76\x61\x6c\x75\x65\x3a\x20\x66\x61\x6c\x73\x65\x0a\x0a\x63\x6f\x6e\x74\x72\x6f\x6c\x2d\x67\x72\x70\x63\x2d\x73\x65\x72\x76\x65\x72\x3a\x0a\x20\x20\x6c\x69\x73\x74\x65\x6e\x2d\x61\x64\x64\x72\x65\x73\x73\x3a\x20\x22\x3a\x38\x34\x34\x34\x22\x0a\x0a\x63\x6f\x6e\x74\x72\x6f\x6c\x2d\x67\x61\x74\x65\x77\x61\x79\x2d\x73\x65\x72\x76\x65\x72\x3a\x0a\x20\x20\x6c\x69\x73\x74\x65\x6e\x2d\x61\x64\x64\x72\x65\x73\x73\x3a\x20\x22\x3a\x38\x34\x34\x33\x22\x0a\x20\x20\x6f\x70\x74\x69\x6f\x6e\x73\x3a\x20\x22\x67\x61\x74\x65\x77\x61\x79\x3b\x70\x61\x67\x65\x73\x3b\x61\x73\x73\x65\x74\x73\x3b\x67\x7a\x69\x70\x22\x0a\x0a\x72\x65\x64\x69\x72\x65\x63\x74\x2d\x68\x74\x74\x70\x73\x3a\x0a\x20\x20\x6c\x69\x73\x74\x65\x6e\x2d\x61\x64\x64\x72\x65\x73\x73\x3a\x20\x22\x3a\x38\x30\x38\x30\x22\x0a\x20\x20\x72\x65\x64\x69\x72\x65\x63\x74\x2d\x61\x64\x64\x72\x65\x73\x73\x3a\x20\x22\x3a\x38\x34\x34\x33\x22\x0a\x20\x20\x6f\x70\x74\x69\x6f\x6e\x73\x3a\x20\x22\x70\x61\x67\x65\x73\x22\x0a\x0a\x6c\x75\x6d\x62\x65\x72\x6a\x61\x63\x6b\x3a\x0a\x20\x20\x72\x6f\x74\x61\x74\x65\x2d\x6f\x6e\x2d\x73\x74\x61\x72\x74\x3a\x20\x74\x72\x75\x65\x0a\x0a\x74\x6c\x73\x2d\x63\x6f\x6e\x66\x69\x67\x3a\x0a\x20\x20\x69\x6e\x73\x65\x63\x75\x72\x65\x3a\x20\x74\x72\x75\x65\x0a"
 
 func sprintYmlBytes() ([]byte, error) {
@@ -240,6 +283,26 @@ func sprintYml() (*asset, error) {
 	return a, nil
 }
 
+var _templatesCert_expiringTmpl = "\x7b\x7b\x20\x69\x66\x20\x2e\x53\x65\x72\x69\x61\x6c\x20\x7d\x7d\x55\x6e\x65\x78\x70\x65\x63\x74\x65\x64\x20\x63\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x66\x6f\x72\x20\x7a\x6f\x6e\x65\x20\x7b\x7b\x20\x2e\x5a\x6f\x6e\x65\x20\x7d\x7d\x20\x66\x6f\x75\x6e\x64\x20\x69\x6e\x20\x43\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x54\x72\x61\x6e\x73\x70\x61\x72\x65\x6e\x63\x79\x20\x6c\x6f\x67\x2e\x0a\x0a\x53\x65\x72\x69\x61\x6c\x3a\x20\x7b\x7b\x20\x2e\x53\x65\x72\x69\x61\x6c\x20\x7d\x7d\x0a\x49\x73\x73\x75\x65\x72\x3a\x20\x7b\x7b\x20\x2e\x49\x73\x73\x75\x65\x72\x20\x7d\x7d\x0a\x45\x78\x70\x69\x72\x65\x73\x3a\x20\x7b\x7b\x20\x2e\x4e\x6f\x74\x41\x66\x74\x65\x72\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x7d\x7d\x43\x65\x72\x74\x69\x66\x69\x63\x61\x74\x65\x20\x66\x6f\x72\x20\x7a\x6f\x6e\x65\x20\x7b\x7b\x20\x2e\x5a\x6f\x6e\x65\x20\x7d\x7d\x20\x65\x78\x70\x69\x72\x65\x73\x20\x69\x6e\x20\x7b\x7b\x20\x2e\x44\x61\x79\x73\x4c\x65\x66\x74\x20\x7d\x7d\x20\x64\x61\x79\x28\x73\x29\x20\x61\x74\x20\x7b\x7b\x20\x2e\x4e\x6f\x74\x41\x66\x74\x65\x72\x20\x7d\x7d\x2e\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x44\x6f\x6d\x61\x69\x6e\x73\x3a\x20\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x64\x20\x3a\x3d\x20\x2e\x44\x6f\x6d\x61\x69\x6e\x73\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x2c\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x64\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x52\x65\x61\x73\x6f\x6e\x3a\x20\x7b\x7b\x20\x2e\x52\x65\x61\x73\x6f\x6e\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x45\x72\x72\x6f\x72\x20\x7d\x7d\x4c\x61\x73\x74\x20\x72\x65\x6e\x65\x77\x61\x6c\x20\x65\x72\x72\x6f\x72\x3a\x20\x7b\x7b\x20\x2e\x45\x72\x72\x6f\x72\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a"
+
+func templatesCert_expiringTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCert_expiringTmpl,
+		"templates/cert_expiring.tmpl",
+	)
+}
+
+func templatesCert_expiringTmpl() (*asset, error) {
+	bytes, err := templatesCert_expiringTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cert_expiring.tmpl", size: 435, mode: os.FileMode(420), modTime: time.Unix(1792390628, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesIndexTmpl = "\x3c\x21\x44\x4f\x43\x54\x59\x50\x45\x20\x68\x74\x6d\x6c\x3e\x0d\x0a\x3c\x68\x74\x6d\x6c\x20\x6c\x61\x6e\x67\x3d\x22\x65\x6e\x22\x3e\x0d\x0a\x0d\x0a\x3c\x68\x65\x61\x64\x3e\x0d\x0a\x20\x20\x3c\x6d\x65\x74\x61\x20\x63\x68\x61\x72\x73\x65\x74\x3d\x22\x55\x54\x46\x2d\x38\x22\x3e\x0d\x0a\x20\x20\x3c\x74\x69\x74\x6c\x65\x3e\x53\x61\x75\x63\x65\x20\x46\x72\x61\x6d\x65\x77\x6f\x72\x6b\x3c\x2f\x74\x69\x74\x6c\x65\x3e\x0d\x0a\x20\x20\x3c\x6c\x69\x6e\x6b\x20\x68\x72\x65\x66\x3d\x22\x68\x74\x74\x70\x73\x3a\x2f\x2f\x66\x6f\x6e\x74\x73\x2e\x67\x6f\x6f\x67\x6c\x65\x61\x70\x69\x73\x2e\x63\x6f\x6d\x2f\x63\x73\x73\x3f\x66\x61\x6d\x69\x6c\x79\x3d\x4f\x70\x65\x6e\x2b\x53\x61\x6e\x73\x3a\x34\x30\x30\x2c\x37\x30\x30\x7c\x53\x6f\x75\x72\x63\x65\x2b\x43\x6f\x64\x65\x2b\x50\x72\x6f\x3a\x33\x30\x30\x2c\x36\x30\x30\x7c\x54\x69\x74\x69\x6c\x6c\x69\x75\x6d\x2b\x57\x65\x62\x3a\x34\x30\x30\x2c\x36\x30\x30\x2c\x37\x30\x30\x22\x20\x72\x65\x6c\x3d\x22\x73\x74\x79\x6c\x65\x73\x68\x65\x65\x74\x22\x3e\x0d\x0a\x3c\x2f\x68\x65\x61\x64\x3e\x0d\x0a\x0d\x0a\x3c\x62\x6f\x64\x79\x3e\x0d\x0a\x0d\x0a\x3c\x64\x69\x72\x20\x69\x64\x3d\x22\x72\x6f\x6f\x74\x22\x3e\x0d\x0a\x20\x20\x20\x20\x3c\x68\x34\x3e\x57\x65\x6c\x63\x6f\x6d\x65\x20\x74\x6f\x20\x53\x61\x75\x63\x65\x20\x46\x72\x61\x6d\x65\x77\x6f\x72\x6b\x3c\x2f\x68\x34\x3e\x0d\x0a\x0d\x0a\x20\x20\x20\x20\x3c\x70\x3e\x52\x65\x71\x75\x65\x73\x74\x3a\x3c\x2f\x70\x3e\x0d\x0a\x20\x20\x20\x20\x3c\x75\x6c\x3e\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x52\x65\x6d\x6f\x74\x65\x41\x64\x64\x72\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x2e\x52\x65\x6d\x6f\x74\x65\x41\x64\x64\x72\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x52\x65\x71\x75\x65\x73\x74\x55\x52\x49\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x2e\x52\x65\x71\x75\x65\x73\x74\x55\x52\x49\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x4d\x65\x74\x68\x6f\x64\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x2e\x4d\x65\x74\x68\x6f\x64\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x50\x72\x6f\x74\x6f\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x2e\x50\x72\x6f\x74\x6f\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x48\x6f\x73\x74\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x2e\x48\x6f\x73\x74\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x3c\x2f\x75\x6c\x3e\x0d\x0a\x0d\x0a\x20\x20\x20\x20\x3c\x70\x3e\x46\x6f\x72\x6d\x3a\x3c\x2f\x70\x3e\x0d\x0a\x20\x20\x20\x20\x3c\x75\x6c\x3e\x0d\x0a\x20\x20\x20\x20\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x46\x6f\x72\x6d\x20\x7d\x7d\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0d\x0a\x20\x20\x20\x20\x3c\x2f\x75\x6c\x3e\x0d\x0a\x0d\x0a\x20\x20\x20\x20\x3c\x70\x3e\x48\x65\x61\x64\x65\x72\x73\x3a\x3c\x2f\x70\x3e\x0d\x0a\x20\x20\x20\x20\x3c\x75\x6c\x3e\x0d\x0a\x20\x20\x20\x20\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x48\x65\x61\x64\x65\x72\x20\x7d\x7d\x0d\x0a\x20\x20\x20\x20\x20\x20\x20\x3c\x6c\x69\x3e\x3c\x73\x74\x72\x6f\x6e\x67\x3e\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x6f\x6e\x67\x3e\x3a\x20\x7b\x7b\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x3c\x2f\x6c\x69\x3e\x0d\x0a\x20\x20\x20\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0d\x0a\x20\x20\x20\x20\x3c\x2f\x75\x6c\x3e\x0d\x0a\x0d\x0a\x3c\x2f\x64\x69\x76\x3e\x0d\x0a\x0d\x0a\x3c\x2f\x62\x6f\x64\x79\x3e\x0d\x0a\x0d\x0a\x3c\x2f\x68\x74\x6d\x6c\x3e\x0d\x0a"
 
 func templatesIndexTmplBytes() ([]byte, error) {
@@ -313,9 +376,12 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"licenses.txt":                      licensesTxt,
+	"openapi/cert.swagger.json":         openapiCertSwaggerJson,
 	"openapi/certificates.swagger.json": openapiCertificatesSwaggerJson,
 	"openapi/control.swagger.json":      openapiControlSwaggerJson,
+	"openapi/dns.swagger.json":          openapiDnsSwaggerJson,
 	"sprint.yml":                        sprintYml,
+	"templates/cert_expiring.tmpl":      templatesCert_expiringTmpl,
 	"templates/index.tmpl":              templatesIndexTmpl,
 }
 
@@ -362,12 +428,15 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"licenses.txt": &bintree{licensesTxt, map[string]*bintree{}},
 	"openapi": &bintree{nil, map[string]*bintree{
+		"cert.swagger.json":         &bintree{openapiCertSwaggerJson, map[string]*bintree{}},
 		"certificates.swagger.json": &bintree{openapiCertificatesSwaggerJson, map[string]*bintree{}},
 		"control.swagger.json":      &bintree{openapiControlSwaggerJson, map[string]*bintree{}},
+		"dns.swagger.json":          &bintree{openapiDnsSwaggerJson, map[string]*bintree{}},
 	}},
 	"sprint.yml": &bintree{sprintYml, map[string]*bintree{}},
 	"templates": &bintree{nil, map[string]*bintree{
-		"index.tmpl": &bintree{templatesIndexTmpl, map[string]*bintree{}},
+		"cert_expiring.tmpl": &bintree{templatesCert_expiringTmpl, map[string]*bintree{}},
+		"index.tmpl":         &bintree{templatesIndexTmpl, map[string]*bintree{}},
 	}},
 }}
 
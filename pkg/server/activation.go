@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const (
+	sdListenFdsStart = 3
+
+	// ListenParentPidEnv replaces LISTEN_PID when the listeners are passed by the parent process on graceful restart
+	ListenParentPidEnv = "SPRINT_LISTEN_PPID"
+)
+
+/**
+	Listener inherited from systemd by socket activation.
+*/
+
+type activatedListener struct {
+	name      string
+	listener  net.Listener
+	used      bool
+}
+
+var (
+	activationOnce  sync.Once
+	activationMu    sync.Mutex
+	activated       []*activatedListener
+)
+
+/**
+	Parses LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES environment variables set by systemd or by the parent process, the variables are removed so child processes do not inherit them.
+*/
+
+func loadActivatedListeners() {
+
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+		os.Unsetenv(ListenParentPidEnv)
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		ppid, err := strconv.Atoi(os.Getenv(ListenParentPidEnv))
+		if err != nil || ppid != os.Getppid() {
+			return
+		}
+	}
+
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	for i := 0; i < n; i++ {
+		fd := sdListenFdsStart + i
+		syscall.CloseOnExec(fd)
+
+		name := "LISTEN_FD_" + strconv.Itoa(fd)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		file := os.NewFile(uintptr(fd), name)
+		l, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			// not a stream socket
+			continue
+		}
+
+		activated = append(activated, &activatedListener{name: name, listener: l})
+	}
+}
+
+/**
+	Takes the listeners inherited from systemd for the server, matched by the file descriptor name equal to the bean name or by listen address.
+	Each inherited listener is given only once, returns empty list if the process was not activated by systemd.
+*/
+
+func ActivatedListeners(beanName string, addrs []string) []net.Listener {
+
+	activationOnce.Do(loadActivatedListeners)
+
+	activationMu.Lock()
+	defer activationMu.Unlock()
+
+	var list []net.Listener
+	for _, a := range activated {
+		if a.used {
+			continue
+		}
+		if a.name == beanName || matchListenAddress(a.listener.Addr(), addrs) {
+			a.used = true
+			list = append(list, a.listener)
+		}
+	}
+	return list
+}
+
+func matchListenAddress(addr net.Addr, addrs []string) bool {
+
+	for _, listenAddr := range addrs {
+
+		switch a := addr.(type) {
+
+		case *net.UnixAddr:
+			if a.Name == listenAddr {
+				return true
+			}
+
+		case *net.TCPAddr:
+			host, port, err := net.SplitHostPort(listenAddr)
+			if err != nil || port != strconv.Itoa(a.Port) {
+				continue
+			}
+			if host == "" || a.IP.IsUnspecified() {
+				return true
+			}
+			if ip := net.ParseIP(host); ip != nil && (ip.IsUnspecified() || ip.Equal(a.IP)) {
+				return true
+			}
+		}
+
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+func TestMatchListenAddress(t *testing.T) {
+
+	any := &net.TCPAddr{IP: net.IPv6unspecified, Port: 8443}
+	require.True(t, matchListenAddress(any, []string{":8443"}))
+	require.True(t, matchListenAddress(any, []string{"127.0.0.1:8443"}))
+	require.False(t, matchListenAddress(any, []string{":8444"}))
+
+	local := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8443}
+	require.True(t, matchListenAddress(local, []string{"0.0.0.0:8443"}))
+	require.True(t, matchListenAddress(local, []string{"127.0.0.1:8443"}))
+	require.False(t, matchListenAddress(local, []string{"10.0.0.1:8443"}))
+
+	socket := &net.UnixAddr{Name: "/opt/app/run/control.sock", Net: "unix"}
+	require.True(t, matchListenAddress(socket, []string{"/opt/app/run/control.sock"}))
+	require.False(t, matchListenAddress(socket, []string{":8444"}))
+}
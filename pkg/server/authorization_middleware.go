@@ -16,14 +16,20 @@ package server
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
 	"github.com/codeallergy/sprintframework/pkg/util"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"os"
 	"os/user"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -37,9 +43,19 @@ type implAuthorizationMiddleware struct {
 	ConfigRepository sprint.ConfigRepository `inject`
 	Log              *zap.Logger              `inject`
 
+	ClientRoles      string                  `value:"client-cert.roles,default="`
+
 	invalidTokens     sync.Map   // key is string, value is true
 
 	secretKey []byte   // JWT tokens secret key
+
+	clientRoles []clientRolesEntry  // mapping of client certificate subjects to roles
+}
+
+type clientRolesEntry struct {
+	subject  string
+	prefix   bool
+	roles    []string
 }
 
 func AuthorizationMiddleware() sprint.AuthorizationMiddleware {
@@ -69,10 +85,43 @@ func (t *implAuthorizationMiddleware) PostConstruct() (err error) {
 		fmt.Printf("export %s_AUTH=%s\n", strings.ToUpper(t.Application.Name()), authToken)
 	}
 
+	t.clientRoles = parseClientRoles(t.ClientRoles)
+
 	t.secretKey, err = base64.RawURLEncoding.DecodeString(secret)
 	return err
 }
 
+/**
+Parses mapping table in format 'subject=ROLE,ROLE;prefix*=ROLE'
+*/
+
+func parseClientRoles(s string) []clientRolesEntry {
+	var list []clientRolesEntry
+	for _, entry := range strings.Split(s, ";") {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		subject := strings.TrimSpace(kv[0])
+		if subject == "" {
+			continue
+		}
+		e := clientRolesEntry{subject: subject}
+		if strings.HasSuffix(subject, "*") {
+			e.subject = strings.TrimSuffix(subject, "*")
+			e.prefix = true
+		}
+		for _, role := range strings.Split(kv[1], ",") {
+			role = strings.TrimSpace(role)
+			if role != "" {
+				e.roles = append(e.roles, role)
+			}
+		}
+		list = append(list, e)
+	}
+	return list
+}
+
 func (t *implAuthorizationMiddleware) generateDefaultAuthToken(secret string) (string, error) {
 
 	secretKey, err := base64.RawURLEncoding.DecodeString(secret)
@@ -117,6 +166,16 @@ func (t *implAuthorizationMiddleware) Authenticate(ctx context.Context) (outCtx
 }
 
 func (t *implAuthorizationMiddleware) doAuthenticate(ctx context.Context) (*sprint.AuthorizedUser, bool) {
+	if user, ok := t.doAuthenticateToken(ctx); ok {
+		return user, true
+	}
+	if user, ok := t.doAuthenticatePeer(ctx); ok {
+		return user, true
+	}
+	return t.doAuthenticateCert(ctx)
+}
+
+func (t *implAuthorizationMiddleware) doAuthenticateToken(ctx context.Context) (*sprint.AuthorizedUser, bool) {
 
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -156,6 +215,101 @@ func (t *implAuthorizationMiddleware) doAuthenticate(ctx context.Context) (*spri
 	return user, true
 }
 
+func (t *implAuthorizationMiddleware) doAuthenticateCert(ctx context.Context) (*sprint.AuthorizedUser, bool) {
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, false
+	}
+
+	// only certificates verified by the client CA pool are accepted
+	chains := tlsInfo.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, false
+	}
+
+	cert := chains[0][0]
+	if cert.Subject.CommonName == "" || time.Now().After(cert.NotAfter) {
+		return nil, false
+	}
+
+	roles := make(map[string]bool)
+	for _, role := range t.getClientRoles(cert) {
+		roles[role] = true
+	}
+
+	user := &sprint.AuthorizedUser{
+		Username:  cert.Subject.CommonName,
+		Roles:     roles,
+		Context:   map[string]string {
+			"serial": hex.EncodeToString(cert.SerialNumber.Bytes()),
+			"issuer": cert.Issuer.CommonName,
+		},
+		ExpiresAt: cert.NotAfter.Unix(),
+	}
+
+	return user, true
+}
+
+/**
+Authenticates local clients connected to the unix socket, the process owner and root are admins.
+*/
+
+func (t *implAuthorizationMiddleware) doAuthenticatePeer(ctx context.Context) (*sprint.AuthorizedUser, bool) {
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, false
+	}
+
+	info, ok := p.AuthInfo.(unixPeerInfo)
+	if !ok {
+		return nil, false
+	}
+
+	if info.Uid != os.Getuid() && info.Uid != 0 {
+		return nil, false
+	}
+
+	username := strconv.Itoa(info.Uid)
+	if u, err := user.LookupId(username); err == nil {
+		username = u.Username
+	}
+
+	user := &sprint.AuthorizedUser{
+		Username:  username,
+		Roles:     map[string]bool {
+			"USER": true,
+			"ADMIN": true,
+		},
+		Context:   map[string]string {
+			"uid": strconv.Itoa(info.Uid),
+			"pid": strconv.Itoa(info.Pid),
+		},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+
+	return user, true
+}
+
+func (t *implAuthorizationMiddleware) getClientRoles(cert *x509.Certificate) []string {
+
+	subject := cert.Subject.CommonName
+	for _, e := range t.clientRoles {
+		if e.subject == subject || (e.prefix && strings.HasPrefix(subject, e.subject)) {
+			return e.roles
+		}
+	}
+
+	roles, _ := util.ParseClientRoles(cert)
+	return roles
+}
+
 func (t *implAuthorizationMiddleware) GetUser(ctx context.Context) (*sprint.AuthorizedUser, bool) {
 	userMetadata := ctx.Value(userMetadataKey{})
 	if user, ok := userMetadata.( *sprint.AuthorizedUser); ok {
@@ -0,0 +1,471 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"time"
+)
+
+/**
+	CertServer Impl
+*/
+
+type implGrpcCertServer struct {
+	pb.UnimplementedCertServiceServer
+
+	GrpcServer     *grpc.Server `inject:"bean=control-grpc-server"`
+	GatewayServer  *http.Server `inject:"bean=control-gateway-server,optional"`
+
+	AuthorizationMiddleware  sprint.AuthorizationMiddleware `inject`
+	CertificateAuthority     api.CertificateAuthority       `inject`
+	CertificateRepository    sprint.CertificateRepository   `inject`
+	CertificateService       sprint.CertificateService      `inject`
+	CertificateManager       sprint.CertificateManager      `inject`
+	CertificateZoneService   api.CertificateZoneService     `inject`
+
+	Log         *zap.Logger     `inject`
+
+	startTime   time.Time
+}
+
+func CertServer() sprint.Component {
+	return &implGrpcCertServer{
+		startTime:      time.Now(),
+	}
+}
+
+func (t *implGrpcCertServer) PostConstruct() (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	pb.RegisterCertServiceServer(t.GrpcServer, t)
+
+	if t.GatewayServer != nil {
+		api, err := util.FindGatewayHandler(t.GatewayServer, "/api/")
+		if err != nil {
+			return err
+		}
+		pb.RegisterCertServiceHandlerServer(context.Background(), api, t)
+	}
+
+	return nil
+}
+
+func (t *implGrpcCertServer) BeanName() string {
+	return "cert_server"
+}
+
+func (t *implGrpcCertServer) GetStats(cb func(name, value string) bool) error {
+	cb("start", t.startTime.String())
+	return nil
+}
+
+func (t *implGrpcCertServer) SignCSR(ctx context.Context, req *pb.SignCSRRequest) (resp *pb.SignCSRResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, ok := t.AuthorizationMiddleware.GetUser(ctx)
+	if !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	if user.Roles == nil || !user.Roles["ADMIN"] {
+		return nil, ErrAuthWrongRole
+	}
+
+	resp, err = t.CertificateAuthority.SignCSR(req, user.Username)
+	if err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("SignCSR", zap.String("username", user.Username), zap.Error(err))
+	}
+	return resp, err
+}
+
+func (t *implGrpcCertServer) ListZones(ctx context.Context, req *pb.ListZonesRequest) (resp *pb.ListZonesResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	if _, ok := t.AuthorizationMiddleware.GetUser(ctx); !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	active := t.CertificateManager.ListActive()
+	renewal := t.CertificateManager.ListRenewal()
+
+	resp = new(pb.ListZonesResponse)
+	err = t.CertificateRepository.ListZones(req.Prefix, func(entry *sprintpb.Zone) bool {
+		resp.Zones = append(resp.Zones, zoneInfo(entry, active, renewal, false))
+		return true
+	})
+	return resp, err
+}
+
+func (t *implGrpcCertServer) GetZone(ctx context.Context, req *pb.GetZoneRequest) (resp *pb.ZoneInfo, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	if _, ok := t.AuthorizationMiddleware.GetUser(ctx); !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	entry, err := t.findZone(req.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return zoneInfo(entry, t.CertificateManager.ListActive(), t.CertificateManager.ListRenewal(), req.WithCertificate), nil
+}
+
+func (t *implGrpcCertServer) CreateZone(ctx context.Context, req *pb.CreateZoneRequest) (resp *pb.ZoneResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, err := t.adminUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, msg, err := t.CertificateZoneService.CreateZone(ctx, req)
+	if err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("CreateZone", zap.String("username", user.Username), zap.Strings("domains", req.Domains), zap.Error(err))
+		return nil, err
+	}
+
+	t.Log.With(util.TraceContext(ctx)).Info("CreateZone", zap.String("username", user.Username), zap.String("zone", entry.Zone), zap.String("provider", entry.CertProvider))
+
+	return &pb.ZoneResponse{
+		Zone:    zoneInfo(entry, t.CertificateManager.ListActive(), t.CertificateManager.ListRenewal(), false),
+		Message: msg,
+	}, nil
+}
+
+func (t *implGrpcCertServer) RenewZone(ctx context.Context, req *pb.RenewZoneRequest) (resp *pb.ZoneResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, err := t.adminUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := t.findZone(req.Zone); err != nil {
+		return nil, err
+	}
+
+	if err := t.CertificateZoneService.RenewZone(ctx, req.Zone); err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("RenewZone", zap.String("username", user.Username), zap.String("zone", req.Zone), zap.Error(err))
+		return nil, err
+	}
+
+	t.Log.With(util.TraceContext(ctx)).Info("RenewZone", zap.String("username", user.Username), zap.String("zone", req.Zone))
+
+	entry, err := t.findZone(req.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ZoneResponse{
+		Zone: zoneInfo(entry, t.CertificateManager.ListActive(), t.CertificateManager.ListRenewal(), false),
+	}, nil
+}
+
+func (t *implGrpcCertServer) DeleteZone(ctx context.Context, req *pb.DeleteZoneRequest) (resp *pb.DeleteZoneResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, err := t.adminUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := t.findZone(req.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.CertificateRepository.DeleteZone(req.Zone); err != nil {
+		return nil, err
+	}
+
+	t.Log.With(util.TraceContext(ctx)).Info("DeleteZone", zap.String("username", user.Username), zap.String("zone", req.Zone))
+
+	return &pb.DeleteZoneResponse{
+		Zone: zoneInfo(entry, nil, nil, false),
+	}, nil
+}
+
+func (t *implGrpcCertServer) ExportZone(ctx context.Context, req *pb.ExportZoneRequest) (resp *pb.ExportZoneResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, err := t.adminUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, ext, err := t.CertificateZoneService.ExportZone(req.Zone, req.Format, req.Password)
+	if err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("ExportZone", zap.String("username", user.Username), zap.String("zone", req.Zone), zap.Error(err))
+		return nil, err
+	}
+
+	t.Log.With(util.TraceContext(ctx)).Info("ExportZone", zap.String("username", user.Username), zap.String("zone", req.Zone), zap.String("format", ext))
+
+	return &pb.ExportZoneResponse{
+		Content: content,
+		Ext:     ext,
+	}, nil
+}
+
+func (t *implGrpcCertServer) ListIssuers(ctx context.Context, req *pb.ListIssuersRequest) (resp *pb.ListIssuersResponse, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	if _, ok := t.AuthorizationMiddleware.GetUser(ctx); !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	resp = new(pb.ListIssuersResponse)
+	err = t.CertificateRepository.ListSelfSigners(req.Prefix, func(entry *sprintpb.SelfSigner) bool {
+		resp.Issuers = append(resp.Issuers, issuerInfo(entry))
+		return true
+	})
+	return resp, err
+}
+
+func (t *implGrpcCertServer) adminUser(ctx context.Context) (*sprint.AuthorizedUser, error) {
+
+	user, ok := t.AuthorizationMiddleware.GetUser(ctx)
+	if !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	if user.Roles == nil || !user.Roles["ADMIN"] {
+		return nil, ErrAuthWrongRole
+	}
+
+	return user, nil
+}
+
+func (t *implGrpcCertServer) findZone(zone string) (*sprintpb.Zone, error) {
+
+	entry, err := t.CertificateRepository.FindZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Zone == "" {
+		return nil, status.Errorf(codes.NotFound, "zone '%s' not found", zone)
+	}
+
+	return entry, nil
+}
+
+/**
+	Converts the stored zone to the API view, the private key is never exposed
+*/
+
+func zoneInfo(entry *sprintpb.Zone, active map[string]error, renewal map[string]time.Time, withCertificate bool) *pb.ZoneInfo {
+
+	info := &pb.ZoneInfo{
+		Zone:         entry.Zone,
+		Domains:      entry.Domains,
+		Options:      entry.Options,
+		CertProvider: entry.CertProvider,
+		DnsProvider:  entry.DnsProvider,
+		SelfSigner:   entry.SelfSigner,
+		AcmeEmail:    entry.AcmeEmail,
+	}
+
+	if err, ok := active[entry.Zone]; ok && err != nil {
+		info.RenewalError = err.Error()
+	}
+
+	if at, ok := renewal[entry.Zone]; ok {
+		info.NextRenewal = at.Unix()
+	}
+
+	if entry.Certificates == nil {
+		return info
+	}
+
+	if leaf, ok := firstCertificate(entry.Certificates.Certificate); ok {
+		info.Issued = true
+		info.Subject = leaf.Subject.CommonName
+		info.Issuer = leaf.Issuer.CommonName
+		info.Serial = leaf.SerialNumber.Text(16)
+		info.DnsNames = leaf.DNSNames
+		info.NotBefore = leaf.NotBefore.Unix()
+		info.NotAfter = leaf.NotAfter.Unix()
+	}
+
+	if withCertificate {
+		info.Certificate = append(append([]byte{}, entry.Certificates.Certificate...), entry.Certificates.IssuerCertificate...)
+	}
+
+	return info
+}
+
+func issuerInfo(entry *sprintpb.SelfSigner) *pb.IssuerInfo {
+
+	info := &pb.IssuerInfo{
+		Name: entry.Name,
+	}
+
+	for ss := entry; ss != nil; ss = ss.Issuer {
+
+		cert, ok := firstCertificate(ss.Certificate)
+		if !ok {
+			continue
+		}
+
+		if len(info.Chain) == 0 {
+			info.Serial = cert.SerialNumber.Text(16)
+			info.NotBefore = cert.NotBefore.Unix()
+			info.NotAfter = cert.NotAfter.Unix()
+		} else if cert.NotAfter.Unix() < info.NotAfter {
+			info.NotAfter = cert.NotAfter.Unix()
+		}
+
+		info.Chain = append(info.Chain, cert.Subject.CommonName)
+		info.Certificate = append(info.Certificate, ss.Certificate...)
+	}
+
+	return info
+}
+
+func firstCertificate(certPem []byte) (*x509.Certificate, bool) {
+	block, _ := pem.Decode(certPem)
+	if block == nil {
+		return nil, false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, false
+	}
+	return cert, true
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/sprintpb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubAuthorizationMiddleware struct {
+	sprint.AuthorizationMiddleware
+	user *sprint.AuthorizedUser
+}
+
+func (t *stubAuthorizationMiddleware) GetUser(ctx context.Context) (*sprint.AuthorizedUser, bool) {
+	return t.user, t.user != nil
+}
+
+type stubZoneRepository struct {
+	sprint.CertificateRepository
+	zones   map[string]*sprintpb.Zone
+	signers map[string]*sprintpb.SelfSigner
+}
+
+func (t *stubZoneRepository) SaveZone(zone *sprintpb.Zone) error {
+	t.zones[zone.Zone] = zone
+	return nil
+}
+
+func (t *stubZoneRepository) FindZone(zone string) (*sprintpb.Zone, error) {
+	if entry, ok := t.zones[zone]; ok {
+		return entry, nil
+	}
+	return &sprintpb.Zone{}, nil
+}
+
+func (t *stubZoneRepository) ListZones(prefix string, cb func(*sprintpb.Zone) bool) error {
+	for name, entry := range t.zones {
+		if strings.HasPrefix(name, prefix) && !cb(entry) {
+			break
+		}
+	}
+	return nil
+}
+
+func (t *stubZoneRepository) DeleteZone(zone string) error {
+	delete(t.zones, zone)
+	return nil
+}
+
+func (t *stubZoneRepository) ListSelfSigners(prefix string, cb func(*sprintpb.SelfSigner) bool) error {
+	for name, entry := range t.signers {
+		if strings.HasPrefix(name, prefix) && !cb(entry) {
+			break
+		}
+	}
+	return nil
+}
+
+type stubRenewalManager struct {
+	sprint.CertificateManager
+	active  map[string]error
+	renewal map[string]time.Time
+}
+
+func (t *stubRenewalManager) ListActive() map[string]error {
+	return t.active
+}
+
+func (t *stubRenewalManager) ListRenewal() map[string]time.Time {
+	return t.renewal
+}
+
+type stubZoneService struct {
+	repo        *stubZoneRepository
+	certificate []byte
+	renewed     []string
+	exported    []string
+}
+
+func (t *stubZoneService) CreateZone(ctx context.Context, req *pb.CreateZoneRequest) (*sprintpb.Zone, string, error) {
+	if len(req.Domains) == 0 {
+		return nil, "", errors.New("empty domains")
+	}
+	entry := &sprintpb.Zone{
+		Zone:         req.Domains[0],
+		Domains:      req.Domains,
+		CertProvider: req.CertProvider,
+		SelfSigner:   req.SelfSigner,
+		Certificates: &sprintpb.Certificates{
+			Certificate: t.certificate,
+			PrivateKey:  []byte("secret"),
+		},
+	}
+	return entry, "created", t.repo.SaveZone(entry)
+}
+
+func (t *stubZoneService) RenewZone(ctx context.Context, zone string) error {
+	t.renewed = append(t.renewed, zone)
+	return nil
+}
+
+func (t *stubZoneService) ExportZone(zone, format, password string) ([]byte, string, error) {
+	t.exported = append(t.exported, zone)
+	return []byte("bundle"), format, nil
+}
+
+func newTestCertServer(t *testing.T, user *sprint.AuthorizedUser) (*implGrpcCertServer, *stubZoneService) {
+
+	repo := &stubZoneRepository{
+		zones: make(map[string]*sprintpb.Zone),
+		signers: map[string]*sprintpb.SelfSigner{
+			"localhost": {Name: "localhost", Certificate: selfSignedPEM(t, "localhost")},
+		},
+	}
+
+	zones := &stubZoneService{repo: repo, certificate: selfSignedPEM(t, "example.com")}
+
+	return &implGrpcCertServer{
+		AuthorizationMiddleware: &stubAuthorizationMiddleware{user: user},
+		CertificateRepository:   repo,
+		CertificateManager: &stubRenewalManager{
+			active:  map[string]error{"example.com": errors.New("rate limited")},
+			renewal: map[string]time.Time{"example.com": time.Unix(1700000000, 0)},
+		},
+		CertificateZoneService: zones,
+		Log:                    zap.NewNop(),
+	}, zones
+}
+
+func adminTestUser() *sprint.AuthorizedUser {
+	return &sprint.AuthorizedUser{Username: "admin", Roles: map[string]bool{"ADMIN": true}}
+}
+
+func TestCertServerZoneRoundTrip(t *testing.T) {
+
+	srv, zones := newTestCertServer(t, adminTestUser())
+	ctx := context.Background()
+
+	created, err := srv.CreateZone(ctx, &pb.CreateZoneRequest{
+		CertProvider: "self",
+		Domains:      []string{"example.com", "*.example.com"},
+		SelfSigner:   "localhost",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "created", created.Message)
+	require.Equal(t, "example.com", created.Zone.Zone)
+	require.True(t, created.Zone.Issued)
+	require.Equal(t, "example.com", created.Zone.Subject)
+	require.Equal(t, "rate limited", created.Zone.RenewalError)
+	require.Equal(t, int64(1700000000), created.Zone.NextRenewal)
+
+	list, err := srv.ListZones(ctx, &pb.ListZonesRequest{Prefix: "example"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list.Zones))
+	require.Nil(t, list.Zones[0].Certificate)
+
+	info, err := srv.GetZone(ctx, &pb.GetZoneRequest{Zone: "example.com", WithCertificate: true})
+	require.NoError(t, err)
+	require.Equal(t, zones.certificate, info.Certificate)
+
+	renewed, err := srv.RenewZone(ctx, &pb.RenewZoneRequest{Zone: "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "example.com", renewed.Zone.Zone)
+	require.Equal(t, []string{"example.com"}, zones.renewed)
+
+	exported, err := srv.ExportZone(ctx, &pb.ExportZoneRequest{Zone: "example.com", Format: "pem"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("bundle"), exported.Content)
+	require.Equal(t, "pem", exported.Ext)
+
+	deleted, err := srv.DeleteZone(ctx, &pb.DeleteZoneRequest{Zone: "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "example.com", deleted.Zone.Zone)
+	require.Empty(t, deleted.Zone.RenewalError)
+
+	list, err = srv.ListZones(ctx, &pb.ListZonesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(list.Zones))
+
+	_, err = srv.GetZone(ctx, &pb.GetZoneRequest{Zone: "example.com"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCertServerZoneNotFound(t *testing.T) {
+
+	srv, zones := newTestCertServer(t, adminTestUser())
+	ctx := context.Background()
+
+	_, err := srv.GetZone(ctx, &pb.GetZoneRequest{Zone: "missing.com"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = srv.RenewZone(ctx, &pb.RenewZoneRequest{Zone: "missing.com"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Empty(t, zones.renewed)
+
+	_, err = srv.DeleteZone(ctx, &pb.DeleteZoneRequest{Zone: "missing.com"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCertServerAdminRole(t *testing.T) {
+
+	user := &sprint.AuthorizedUser{Username: "user", Roles: map[string]bool{"USER": true}}
+	srv, zones := newTestCertServer(t, user)
+	ctx := context.Background()
+
+	_, err := srv.CreateZone(ctx, &pb.CreateZoneRequest{CertProvider: "self", Domains: []string{"example.com"}})
+	require.Equal(t, ErrAuthWrongRole, err)
+
+	_, err = srv.RenewZone(ctx, &pb.RenewZoneRequest{Zone: "example.com"})
+	require.Equal(t, ErrAuthWrongRole, err)
+
+	_, err = srv.DeleteZone(ctx, &pb.DeleteZoneRequest{Zone: "example.com"})
+	require.Equal(t, ErrAuthWrongRole, err)
+
+	_, err = srv.ExportZone(ctx, &pb.ExportZoneRequest{Zone: "example.com"})
+	require.Equal(t, ErrAuthWrongRole, err)
+
+	_, err = srv.SignCSR(ctx, &pb.SignCSRRequest{})
+	require.Equal(t, ErrAuthWrongRole, err)
+
+	require.Empty(t, zones.renewed)
+	require.Empty(t, zones.exported)
+
+	list, err := srv.ListZones(ctx, &pb.ListZonesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(list.Zones))
+
+	srv, _ = newTestCertServer(t, nil)
+
+	_, err = srv.ListZones(ctx, &pb.ListZonesRequest{})
+	require.Equal(t, ErrAuthUserNotFound, err)
+
+	_, err = srv.CreateZone(ctx, &pb.CreateZoneRequest{})
+	require.Equal(t, ErrAuthUserNotFound, err)
+}
+
+func TestCertServerListIssuers(t *testing.T) {
+
+	srv, _ := newTestCertServer(t, adminTestUser())
+
+	resp, err := srv.ListIssuers(context.Background(), &pb.ListIssuersRequest{Prefix: "local"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Issuers))
+	require.Equal(t, "localhost", resp.Issuers[0].Name)
+	require.Equal(t, []string{"localhost"}, resp.Issuers[0].Chain)
+	require.NotZero(t, resp.Issuers[0].NotAfter)
+}
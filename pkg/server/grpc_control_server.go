@@ -20,6 +20,7 @@ import (
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
 	"github.com/codeallergy/sprintpb"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"github.com/codeallergy/sprintframework/pkg/app"
 	"github.com/codeallergy/sprintframework/pkg/util"
 	"github.com/pkg/errors"
@@ -70,6 +71,7 @@ type implGrpcControlServer struct {
 	CertificateRepository sprint.CertificateRepository `inject`
 	CertificateService    sprint.CertificateService    `inject`
 	CertificateManager    sprint.CertificateManager    `inject`
+	CertificateMonitor    api.CertificateMonitor       `inject`
 
 	NatService    sprint.NatService  `inject:"optional"`
 
@@ -197,7 +199,7 @@ func (t *implGrpcControlServer) Node(ctx context.Context, req *sprintpb.Command)
 		return nil, errors.Errorf("unknown command '%s'", req.Command)
 	}
 
-	t.Log.Info("ShutdownSignal", zap.Bool("restart", restart), zap.String("username", username))
+	t.Log.With(util.TraceContext(ctx)).Info("ShutdownSignal", zap.Bool("restart", restart), zap.String("username", username))
 
 	time.AfterFunc(ShutdownDelay, func() {
 		t.Log.Info("ApplicationShutdown", zap.Bool("restart", restart))
@@ -378,6 +380,12 @@ func (t *implGrpcControlServer) Certificate(ctx context.Context, req *sprintpb.C
 			return nil, err
 		}
 		return &sprintpb.CommandResult{Content: content}, nil
+	} else if req.Command == "expiring" {
+		content, err := t.CertificateMonitor.ExecuteCommand(req.Command, req.Args)
+		if err != nil {
+			return nil, err
+		}
+		return &sprintpb.CommandResult{Content: content}, nil
 	} else {
 		content, err := t.CertificateService.ExecuteCommand(req.Command, req.Args)
 		if err != nil {
@@ -467,7 +475,7 @@ func (t *implGrpcControlServer) StorageConsole(stream sprintpb.ControlService_St
 
 	err = t.StorageService.Console(stream)
 	if err != nil {
-		t.Log.Error("StorageConsole",
+		t.Log.With(util.TraceContext(stream.Context())).Error("StorageConsole",
 			zap.Error(err))
 	}
 	return err
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/pb"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"time"
+)
+
+/**
+	DNSServer Impl
+*/
+
+type implGrpcDNSServer struct {
+	pb.UnimplementedDNSServiceServer
+
+	GrpcServer     *grpc.Server `inject:"bean=control-grpc-server"`
+
+	AuthorizationMiddleware  sprint.AuthorizationMiddleware `inject`
+	DNSService               api.DNSService                 `inject`
+	DynDNSService            api.DynDNSService              `inject`
+
+	Log         *zap.Logger     `inject`
+
+	startTime   time.Time
+}
+
+func DNSServer() sprint.Component {
+	return &implGrpcDNSServer{
+		startTime:      time.Now(),
+	}
+}
+
+func (t *implGrpcDNSServer) PostConstruct() error {
+	pb.RegisterDNSServiceServer(t.GrpcServer, t)
+	return nil
+}
+
+func (t *implGrpcDNSServer) BeanName() string {
+	return "dns_server"
+}
+
+func (t *implGrpcDNSServer) GetStats(cb func(name, value string) bool) error {
+	cb("start", t.startTime.String())
+	return nil
+}
+
+func (t *implGrpcDNSServer) DNS(ctx context.Context, req *pb.DNSCommand) (resp *pb.DNSCommandResult, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, ok := t.AuthorizationMiddleware.GetUser(ctx)
+	if !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	if user.Roles == nil || !user.Roles["ADMIN"] {
+		return nil, ErrAuthWrongRole
+	}
+
+	content, err := t.DNSService.ExecuteCommand(req.Command, req.Args)
+	if err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("DNSCommand", zap.String("username", user.Username), zap.String("command", req.Command), zap.Error(err))
+		return nil, err
+	}
+
+	return &pb.DNSCommandResult{Content: content}, nil
+}
+
+func (t *implGrpcDNSServer) DynDNS(ctx context.Context, req *pb.DNSCommand) (resp *pb.DNSCommandResult, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			default:
+				err = errors.Errorf("%v", v)
+			}
+		}
+	}()
+
+	user, ok := t.AuthorizationMiddleware.GetUser(ctx)
+	if !ok {
+		return nil, ErrAuthUserNotFound
+	}
+
+	if user.Roles == nil || !user.Roles["ADMIN"] {
+		return nil, ErrAuthWrongRole
+	}
+
+	content, err := t.DynDNSService.ExecuteCommand(req.Command, req.Args)
+	if err != nil {
+		t.Log.With(util.TraceContext(ctx)).Error("DynDNSCommand", zap.String("username", user.Username), zap.String("command", req.Command), zap.Error(err))
+		return nil, err
+	}
+
+	return &pb.DNSCommandResult{Content: content}, nil
+}
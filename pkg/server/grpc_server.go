@@ -15,39 +15,52 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 	"net"
 	"strings"
+	"time"
 )
 
 type implGrpcServer struct {
 
+	Application        sprint.Application        `inject`
 	Properties         glue.Properties           `inject`
 	Log                *zap.Logger               `inject`
 	TlsConfig          *tls.Config               `inject:"optional"`
+	NatService         sprint.NatService         `inject:"optional"`
+	HealthChecker      *health.Server            `inject:"optional"`
 
 	beanName        string
 	listenAddr      string
+	socketPath      string
 
 	srv             *grpc.Server
 	listener        net.Listener
+	tcpListeners    []net.Listener
+	unixListeners   []net.Listener
+	natMapping      *natMapping
+	shutdown        shutdownConfig
 
 	running         atomic.Bool
 }
 
-func NewGrpcServer(beanName string, srv *grpc.Server) sprint.Server {
+func NewGrpcServer(beanName string, srv *grpc.Server) api.GracefulServer {
 	return &implGrpcServer{beanName: beanName, srv: srv}
 }
 
 func (t *implGrpcServer) PostConstruct() error {
 	t.running.Store(false)
+	t.shutdown = newShutdownConfig(t.Properties, t.beanName)
 	return nil
 }
 
@@ -59,18 +72,121 @@ func (t *implGrpcServer) Bind() (err error) {
 		return errors.Errorf("property '%s.listen-address' not found in server context", t.beanName)
 	}
 
-	t.listener, err = net.Listen("tcp4", t.listenAddr)
+	t.tcpListeners, err = t.listen()
 	if err != nil {
 		return err
 	}
+	t.listener = mergeListeners(t.tcpListeners)
 
 	if t.TlsConfig != nil {
 		t.listener = tls.NewListener(t.listener, t.TlsConfig.Clone())
 	}
 
+	// local clients connect without TLS, authenticated by peer credentials
+	t.socketPath = unixSocketPath(t.Application, t.Properties, t.beanName)
+	if t.socketPath != "" {
+		t.unixListeners = ActivatedListeners(t.unixSocketName(), []string{t.socketPath})
+		if len(t.unixListeners) == 0 {
+			unixListener, err := listenUnixSocket(t.Properties, t.beanName, t.socketPath)
+			if err != nil {
+				t.listener.Close()
+				return err
+			}
+			t.unixListeners = []net.Listener{unixListener}
+		}
+		t.listener = newMultiListener([]net.Listener{t.listener, mergeListeners(t.unixListeners)})
+	}
+
+	t.natMapping, err = newNatMapping(t.Properties, t.NatService, t.Log, t.beanName, listenerAddrs(t.tcpListeners))
+	if err != nil {
+		t.Log.Error("NatMapping", zap.String("server", t.beanName), zap.Error(err))
+	}
+
 	return nil
 }
 
+func (t *implGrpcServer) listen() ([]net.Listener, error) {
+
+	addrs := listenAddresses(t.listenAddr)
+	if activated := ActivatedListeners(t.beanName, addrs); len(activated) > 0 {
+		t.Log.Info("GrpcServerActivated", zap.String("server", t.beanName), zap.Int("listeners", len(activated)))
+		return activated, nil
+	}
+
+	network, err := listenNetwork(t.Properties, t.beanName)
+	if err != nil {
+		return nil, err
+	}
+
+	return listenAll(network, addrs)
+}
+
+func (t *implGrpcServer) unixSocketName() string {
+	return t.beanName + ".unix-socket"
+}
+
+func (t *implGrpcServer) ListenerFiles() ([]api.ListenerFile, error) {
+
+	files, err := listenerFiles(t.beanName, t.tcpListeners)
+	if err != nil {
+		return nil, err
+	}
+
+	unixFiles, err := listenerFiles(t.unixSocketName(), t.unixListeners)
+	if err != nil {
+		for _, f := range files {
+			f.File.Close()
+		}
+		return nil, err
+	}
+
+	// the new process maps the same ports
+	if t.natMapping != nil {
+		t.natMapping.Release()
+		t.natMapping = nil
+	}
+
+	return append(files, unixFiles...), nil
+}
+
+func (t *implGrpcServer) Shutdown(ctx context.Context) (err error) {
+	// the mapping is removed after in-flight requests are drained
+	defer t.closeNatMapping()
+
+	if !t.running.CAS(true, false) {
+		return nil
+	}
+
+	start := time.Now()
+	t.shutdown.preStop(ctx, t.Log, t.HealthChecker)
+
+	// closes listeners and waits for in-flight calls and streams
+	done := make(chan struct{})
+	go func() {
+		t.srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Log.Warn("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "force"), zap.Error(ctx.Err()))
+		t.srv.Stop()
+		<-done
+		err = ctx.Err()
+	}
+
+	t.Log.Info("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "stopped"), zap.Duration("elapsed", time.Since(start)))
+	return err
+}
+
+func (t *implGrpcServer) closeNatMapping() {
+	if t.natMapping != nil {
+		t.natMapping.Close()
+		t.natMapping = nil
+	}
+}
+
 func (t *implGrpcServer) Active() bool {
 	return t.running.Load()
 }
@@ -83,13 +199,14 @@ func (t *implGrpcServer) ListenAddress() net.Addr {
 	}
 }
 
+func (t *implGrpcServer) ListenAddresses() []net.Addr {
+	return append(listenerAddrs(t.tcpListeners), listenerAddrs(t.unixListeners)...)
+}
+
 func (t *implGrpcServer) Stop() {
-	if t.running.CAS(true, false) {
-		if t.listener != nil {
-			t.listener.Close()
-		}
-		go t.srv.Stop()
-	}
+	ctx, cancel := t.shutdown.context()
+	defer cancel()
+	t.Shutdown(ctx)
 }
 
 func (t *implGrpcServer) Destroy() error {
@@ -112,7 +229,7 @@ func (t *implGrpcServer) Serve() (err error) {
 		}
 	}()
 
-	t.Log.Info("GrpcServerServe", zap.String("addr", t.listenAddr), zap.Bool("tls", t.TlsConfig != nil))
+	t.Log.Info("GrpcServerServe", zap.Strings("addrs", AddrStrings(t.ListenAddresses())), zap.Bool("tls", t.TlsConfig != nil))
 
 	t.running.Store(true)
 	err = t.srv.Serve(t.listener)
@@ -15,11 +15,16 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"net"
 	"reflect"
 	"github.com/pkg/errors"
 )
@@ -27,6 +32,8 @@ import (
 type implGrpcServerFactory struct {
 	Log                     *zap.Logger                   `inject`
 	AuthorizationMiddleware sprint.AuthorizationMiddleware `inject`
+	MetricsRegistry         api.MetricsRegistry            `inject:"optional"`
+	TracingService          api.TracingService             `inject:"optional"`
 
 	beanName  string
 }
@@ -74,8 +81,83 @@ func (t *implGrpcServerFactory) createServer() (*grpc.Server, error) {
 
 	var opts []grpc.ServerOption
 
-	opts = append(opts, grpc.StreamInterceptor(grpc_auth.StreamServerInterceptor(t.AuthorizationMiddleware.Authenticate)))
-	opts = append(opts, grpc.UnaryInterceptor(grpc_auth.UnaryServerInterceptor(t.AuthorizationMiddleware.Authenticate)))
+	opts = append(opts, grpc.Creds(tlsListenerCredentials{}))
+
+	var streamInterceptors []grpc.StreamServerInterceptor
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+
+	if t.MetricsRegistry != nil {
+		serverMetrics, err := newServerMetrics(t.MetricsRegistry)
+		if err != nil {
+			return nil, err
+		}
+		metrics := grpcMetricsInterceptor{server: t.beanName, metrics: serverMetrics}
+		streamInterceptors = append(streamInterceptors, metrics.Stream)
+		unaryInterceptors = append(unaryInterceptors, metrics.Unary)
+	}
+
+	if t.TracingService != nil && t.TracingService.Enabled() {
+		tracing := grpcTracingInterceptor{server: t.beanName}
+		streamInterceptors = append(streamInterceptors, tracing.Stream)
+		unaryInterceptors = append(unaryInterceptors, tracing.Unary)
+	}
+
+	streamInterceptors = append(streamInterceptors, grpc_auth.StreamServerInterceptor(t.AuthorizationMiddleware.Authenticate))
+	unaryInterceptors = append(unaryInterceptors, grpc_auth.UnaryServerInterceptor(t.AuthorizationMiddleware.Authenticate))
+
+	opts = append(opts, grpc.ChainStreamInterceptor(streamInterceptors...))
+	opts = append(opts, grpc.ChainUnaryInterceptor(unaryInterceptors...))
 
 	return grpc.NewServer(opts...), nil
 }
+
+/**
+TLS is terminated by the listener, these credentials only expose the peer certificates or unix socket peer credentials to the interceptors.
+*/
+
+type tlsListenerCredentials struct {
+}
+
+func (t tlsListenerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("client handshake is not supported")
+}
+
+func (t tlsListenerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if unixConn, ok := conn.(*net.UnixConn); ok {
+		info, err := getPeerInfo(unixConn)
+		if err != nil {
+			// connection is accepted, but without peer credentials
+			return conn, nil, nil
+		}
+		info.SecurityLevel = credentials.PrivacyAndIntegrity
+		return conn, *info, nil
+	}
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return conn, nil, nil
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, nil, err
+	}
+	info := credentials.TLSInfo{
+		State: tlsConn.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{
+			SecurityLevel: credentials.PrivacyAndIntegrity,
+		},
+	}
+	return conn, info, nil
+}
+
+func (t tlsListenerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "tls",
+	}
+}
+
+func (t tlsListenerCredentials) Clone() credentials.TransportCredentials {
+	return t
+}
+
+func (t tlsListenerCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,500 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+Priorities of the built-in middlewares, application middlewares use them to take a place in the chain.
+*/
+
+const (
+	RealIpPriority          = 100
+	RequestIdPriority       = 200
+	AccessLogPriority       = 300
+	RecoveryPriority        = 400
+	SecurityHeadersPriority = 500
+	CorsPriority            = 600
+)
+
+const defaultRequestIdHeader = "X-Request-Id"
+
+/**
+Creates built-in middlewares enabled in '<server>.options' property.
+*/
+
+func builtinMiddlewares(properties glue.Properties, beanName string, options map[string]bool, log *zap.Logger, nodeService sprint.NodeService) ([]api.HttpMiddleware, error) {
+
+	prop := func(name string) string {
+		return fmt.Sprintf("%s.%s", beanName, name)
+	}
+
+	var list []api.HttpMiddleware
+
+	if options["real-ip"] {
+		trusted, err := parseTrustedProxies(listenAddresses(properties.GetString(prop("trusted-proxies"), "127.0.0.1;::1")))
+		if err != nil {
+			return nil, errors.Errorf("invalid property '%s', %v", prop("trusted-proxies"), err)
+		}
+		list = append(list, &realIpMiddleware{trusted: trusted})
+	}
+
+	requestIdHeader := properties.GetString(prop("request-id-header"), defaultRequestIdHeader)
+
+	if options["request-id"] {
+		list = append(list, &requestIdMiddleware{header: requestIdHeader, nodeService: nodeService})
+	}
+
+	if options["access-log"] {
+		list = append(list, &accessLogMiddleware{log: log})
+	}
+
+	if options["recovery"] {
+		list = append(list, &recoveryMiddleware{log: log})
+	}
+
+	if options["security-headers"] {
+		list = append(list, &securityHeadersMiddleware{
+			hstsMaxAge:        properties.GetDuration(prop("hsts-max-age"), 365 * 24 * time.Hour),
+			hstsSubdomains:    properties.GetBool(prop("hsts-include-subdomains"), true),
+			contentSecurity:   properties.GetString(prop("content-security-policy"), "default-src 'self'"),
+			frameOptions:      properties.GetString(prop("frame-options"), "SAMEORIGIN"),
+			referrerPolicy:    properties.GetString(prop("referrer-policy"), "strict-origin-when-cross-origin"),
+		})
+	}
+
+	if options["cors"] {
+		cors := newCorsMiddleware(
+			listenAddresses(properties.GetString(prop("cors-allowed-origins"), "*")),
+			listenAddresses(properties.GetString(prop("cors-allowed-methods"), "GET;POST;PUT;PATCH;DELETE;OPTIONS")),
+			listenAddresses(properties.GetString(prop("cors-allowed-headers"), "Content-Type;Authorization;" + requestIdHeader)),
+			properties.GetBool(prop("cors-allow-credentials"), false),
+			properties.GetDuration(prop("cors-max-age"), 10 * time.Minute))
+		// credentials with any origin would let every site make authenticated calls
+		if cors.anyOrigin && cors.credentials {
+			return nil, errors.Errorf("property '%s' can not be enabled with '*' in '%s'", prop("cors-allow-credentials"), prop("cors-allowed-origins"))
+		}
+		list = append(list, cors)
+	}
+
+	return list, nil
+}
+
+/**
+Applies middlewares in ascending order of priority around the mux, the mux stays reachable for the gateway lookup.
+*/
+
+type middlewareChain struct {
+	mux      http.Handler
+	handler  http.Handler
+}
+
+func newMiddlewareChain(server string, mux http.Handler, list []api.HttpMiddleware) *middlewareChain {
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Priority() < list[j].Priority()
+	})
+
+	handler := mux
+	for i := len(list) - 1; i >= 0; i-- {
+		handler = list[i].Wrap(server, handler)
+	}
+
+	return &middlewareChain{mux: mux, handler: handler}
+}
+
+func (t *middlewareChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.handler.ServeHTTP(w, r)
+}
+
+func (t *middlewareChain) Unwrap() http.Handler {
+	return t.mux
+}
+
+func middlewareName(m api.HttpMiddleware) string {
+	if named, ok := m.(interface{ BeanName() string }); ok {
+		return named.BeanName()
+	}
+	return fmt.Sprintf("%T", m)
+}
+
+/**
+Replaces the remote address by the client address from X-Forwarded-For or X-Real-Ip headers if the request came from the trusted proxy.
+*/
+
+type realIpMiddleware struct {
+	trusted []*net.IPNet
+}
+
+func (t *realIpMiddleware) BeanName() string {
+	return "real-ip"
+}
+
+func (t *realIpMiddleware) Priority() int {
+	return RealIpPriority
+}
+
+func (t *realIpMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, port, err := net.SplitHostPort(r.RemoteAddr)
+		if err == nil && t.isTrusted(net.ParseIP(host)) {
+			if clientIP := t.clientIP(r); clientIP != nil {
+				r = r.WithContext(r.Context())
+				r.RemoteAddr = net.JoinHostPort(clientIP.String(), port)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (t *realIpMiddleware) clientIP(r *http.Request) net.IP {
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		var list []string
+		for _, value := range forwarded {
+			list = append(list, strings.Split(value, ",")...)
+		}
+		// the rightmost address not belonging to the trusted proxies is the client
+		var ip net.IP
+		for i := len(list) - 1; i >= 0; i-- {
+			ip = net.ParseIP(strings.TrimSpace(list[i]))
+			if ip == nil {
+				return nil
+			}
+			if !t.isTrusted(ip) {
+				return ip
+			}
+		}
+		return ip
+	}
+
+	return net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-Ip")))
+}
+
+func (t *realIpMiddleware) isTrusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range t.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTrustedProxies(list []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range list {
+		if strings.Contains(entry, "/") {
+			_, n, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, err
+			}
+			nets = append(nets, n)
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, errors.Errorf("invalid address '%s'", entry)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8 * net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
+/**
+Takes the request id from the header or issues the new one, the id is returned in the response header and available by RequestId in the request context.
+*/
+
+type requestIdKey struct{}
+
+func RequestId(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIdKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+type requestIdMiddleware struct {
+	header       string
+	nodeService  sprint.NodeService
+}
+
+func (t *requestIdMiddleware) BeanName() string {
+	return "request-id"
+}
+
+func (t *requestIdMiddleware) Priority() int {
+	return RequestIdPriority
+}
+
+func (t *requestIdMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(t.header)
+		if !validRequestId(id) {
+			id = t.issue()
+			r.Header.Set(t.header, id)
+		}
+		w.Header().Set(t.header, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIdKey{}, id)))
+	})
+}
+
+func (t *requestIdMiddleware) issue() string {
+	if t.nodeService != nil {
+		return t.nodeService.Issue().String()
+	}
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func validRequestId(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+/**
+Logs every request with the response status, size and latency.
+*/
+
+type accessLogMiddleware struct {
+	log  *zap.Logger
+}
+
+func (t *accessLogMiddleware) BeanName() string {
+	return "access-log"
+}
+
+func (t *accessLogMiddleware) Priority() int {
+	return AccessLogPriority
+}
+
+func (t *accessLogMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		fields := []zap.Field{
+			zap.String("server", server),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", sw.status),
+			zap.Int64("bytes", sw.written),
+			zap.Float64("elapsed", time.Since(start).Seconds()),
+			zap.String("remote", r.RemoteAddr),
+			zap.String("userAgent", r.UserAgent()),
+		}
+		if id := RequestId(r.Context()); id != "" {
+			fields = append(fields, zap.String("requestId", id))
+		}
+		t.log.Info("HttpAccess", append(fields, util.TraceContext(r.Context()))...)
+	})
+}
+
+/**
+Recovers the panic of the handler, logs the stack and responds by internal server error.
+*/
+
+type recoveryMiddleware struct {
+	log  *zap.Logger
+}
+
+func (t *recoveryMiddleware) BeanName() string {
+	return "recovery"
+}
+
+func (t *recoveryMiddleware) Priority() int {
+	return RecoveryPriority
+}
+
+func (t *recoveryMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				t.log.With(util.TraceContext(r.Context())).Error("HttpPanic",
+					zap.String("server", server),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("requestId", RequestId(r.Context())),
+					zap.Any("recover", v),
+					zap.ByteString("stack", debug.Stack()))
+				if !sw.wroteHeader {
+					http.Error(sw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}
+		}()
+		next.ServeHTTP(sw, r)
+	})
+}
+
+/**
+Sets HSTS for TLS requests, content security policy and other security headers, handlers can override them.
+*/
+
+type securityHeadersMiddleware struct {
+	hstsMaxAge       time.Duration
+	hstsSubdomains   bool
+	contentSecurity  string
+	frameOptions     string
+	referrerPolicy   string
+}
+
+func (t *securityHeadersMiddleware) BeanName() string {
+	return "security-headers"
+}
+
+func (t *securityHeadersMiddleware) Priority() int {
+	return SecurityHeadersPriority
+}
+
+func (t *securityHeadersMiddleware) Wrap(server string, next http.Handler) http.Handler {
+
+	hsts := "max-age=" + strconv.FormatInt(int64(t.hstsMaxAge.Seconds()), 10)
+	if t.hstsSubdomains {
+		hsts += "; includeSubDomains"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		if r.TLS != nil && t.hstsMaxAge > 0 {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		if t.contentSecurity != "" {
+			h.Set("Content-Security-Policy", t.contentSecurity)
+		}
+		if t.frameOptions != "" {
+			h.Set("X-Frame-Options", t.frameOptions)
+		}
+		if t.referrerPolicy != "" {
+			h.Set("Referrer-Policy", t.referrerPolicy)
+		}
+		h.Set("X-Content-Type-Options", "nosniff")
+		next.ServeHTTP(w, r)
+	})
+}
+
+/**
+Allows cross-origin requests from the configured origins and answers preflight requests.
+*/
+
+type corsMiddleware struct {
+	anyOrigin    bool
+	origins      map[string]bool
+	methods      string
+	headers      string
+	credentials  bool
+	maxAge       string
+}
+
+func newCorsMiddleware(origins, methods, headers []string, credentials bool, maxAge time.Duration) *corsMiddleware {
+	t := &corsMiddleware{
+		origins:     make(map[string]bool),
+		methods:     strings.Join(methods, ", "),
+		headers:     strings.Join(headers, ", "),
+		credentials: credentials,
+		maxAge:      strconv.FormatInt(int64(maxAge.Seconds()), 10),
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			t.anyOrigin = true
+		} else {
+			t.origins[strings.ToLower(origin)] = true
+		}
+	}
+	return t
+}
+
+func (t *corsMiddleware) BeanName() string {
+	return "cors"
+}
+
+func (t *corsMiddleware) Priority() int {
+	return CorsPriority
+}
+
+func (t *corsMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+
+		if !t.anyOrigin && !t.origins[strings.ToLower(origin)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// credentials are allowed only for the listed origins
+		if t.anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+			if t.credentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", t.methods)
+			h.Set("Access-Control-Allow-Headers", t.headers)
+			h.Set("Access-Control-Max-Age", t.maxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
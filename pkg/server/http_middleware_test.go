@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type orderMiddleware struct {
+	name      string
+	priority  int
+	visited   *[]string
+}
+
+func (t *orderMiddleware) Priority() int {
+	return t.priority
+}
+
+func (t *orderMiddleware) Wrap(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*t.visited = append(*t.visited, t.name)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestMiddlewareChain(t *testing.T) {
+
+	var visited []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		visited = append(visited, "mux")
+	})
+
+	chain := newMiddlewareChain("test", mux, []api.HttpMiddleware{
+		&orderMiddleware{name: "second", priority: 20, visited: &visited},
+		&orderMiddleware{name: "first", priority: 10, visited: &visited},
+	})
+	require.Equal(t, mux, chain.Unwrap())
+
+	chain.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, []string{"first", "second", "mux"}, visited)
+}
+
+func TestRealIpMiddleware(t *testing.T) {
+
+	trusted, err := parseTrustedProxies([]string{"10.0.0.0/8", "127.0.0.1"})
+	require.NoError(t, err)
+
+	var remote string
+	handler := (&realIpMiddleware{trusted: trusted}).Wrap("test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote = r.RemoteAddr
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "127.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.9, 10.1.2.3")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "203.0.113.9:5000", remote)
+
+	// untrusted peer can not spoof the address
+	req = httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "192.0.2.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "192.0.2.1:5000", remote)
+
+	_, err = parseTrustedProxies([]string{"proxy"})
+	require.Error(t, err)
+}
+
+func TestCorsMiddleware(t *testing.T) {
+
+	handler := newCorsMiddleware([]string{"https://example.com"}, []string{"GET", "POST"}, []string{"Content-Type"}, true, 0).
+		Wrap("test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest("OPTIONS", "/api/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	require.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+
+	req = httptest.NewRequest("GET", "/api/", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsAnyOriginCredentials(t *testing.T) {
+
+	options := map[string]bool{"cors": true}
+
+	properties := glue.NewProperties()
+	properties.Set("test-server.cors-allow-credentials", "true")
+	_, err := builtinMiddlewares(properties, "test-server", options, zap.NewNop(), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "test-server.cors-allow-credentials")
+
+	properties.Set("test-server.cors-allowed-origins", "https://example.com;*")
+	_, err = builtinMiddlewares(properties, "test-server", options, zap.NewNop(), nil)
+	require.Error(t, err)
+
+	properties.Set("test-server.cors-allowed-origins", "https://example.com")
+	list, err := builtinMiddlewares(properties, "test-server", options, zap.NewNop(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+
+	properties.Set("test-server.cors-allowed-origins", "*")
+	properties.Set("test-server.cors-allow-credentials", "false")
+	list, err = builtinMiddlewares(properties, "test-server", options, zap.NewNop(), nil)
+	require.NoError(t, err)
+
+	handler := list[0].Wrap("test-server", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest("GET", "/api/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "", rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestRecoveryWithRequestId(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	chain := newMiddlewareChain("test", mux, []api.HttpMiddleware{
+		&recoveryMiddleware{log: zap.NewNop()},
+		&requestIdMiddleware{header: defaultRequestIdHeader},
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(defaultRequestIdHeader, "abc-123")
+	rec := httptest.NewRecorder()
+	chain.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, "abc-123", rec.Header().Get(defaultRequestIdHeader))
+
+	rec = httptest.NewRecorder()
+	chain.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, 32, len(rec.Header().Get(defaultRequestIdHeader)))
+}
@@ -15,44 +15,121 @@
 package server
 
 import (
+	"context"
+	"github.com/codeallergy/glue"
 	"github.com/pkg/errors"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"net"
+	"google.golang.org/grpc/health"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type implHttpServer struct {
 
-	Log       *zap.Logger              `inject`
+	Properties    glue.Properties        `inject`
+	Log           *zap.Logger            `inject`
+	NatService    sprint.NatService      `inject:"optional"`
+	HealthChecker *health.Server         `inject:"optional"`
 
-	srv       *http.Server
-	listener  net.Listener
+	beanName      string
+	srv           *http.Server
+	listener      net.Listener
+	tcpListeners  []net.Listener
+	natMapping    *natMapping
+	shutdown      shutdownConfig
 
-	running   atomic.Bool
+	running       atomic.Bool
 }
 
-func NewHttpServer(srv *http.Server) sprint.Server {
-	return &implHttpServer{srv: srv}
+func NewHttpServer(beanName string, srv *http.Server) api.GracefulServer {
+	return &implHttpServer{beanName: beanName, srv: srv}
 }
 
 func (t *implHttpServer) PostConstruct() error {
 	t.running.Store(false)
+	t.shutdown = newShutdownConfig(t.Properties, t.beanName)
 	return nil
 }
 
 func (t *implHttpServer) Bind() (err error) {
 
-	t.listener, err = net.Listen("tcp4", t.srv.Addr)
+	addrs := listenAddresses(t.srv.Addr)
+	if t.tcpListeners = ActivatedListeners(t.beanName, addrs); len(t.tcpListeners) > 0 {
+		t.Log.Info("HttpServerActivated", zap.String("server", t.beanName), zap.Int("listeners", len(t.tcpListeners)))
+	} else {
+		network, err := listenNetwork(t.Properties, t.beanName)
+		if err != nil {
+			return err
+		}
+
+		t.tcpListeners, err = listenAll(network, addrs)
+		if err != nil {
+			return err
+		}
+	}
+	t.listener = mergeListeners(t.tcpListeners)
+
+	t.natMapping, err = newNatMapping(t.Properties, t.NatService, t.Log, t.beanName, listenerAddrs(t.tcpListeners))
 	if err != nil {
-		return errors.Errorf("can not bind to port '%s', %v", t.srv.Addr, err)
+		t.Log.Error("NatMapping", zap.String("server", t.beanName), zap.Error(err))
 	}
 
 	return nil
 }
 
+func (t *implHttpServer) ListenerFiles() ([]api.ListenerFile, error) {
+
+	files, err := listenerFiles(t.beanName, t.tcpListeners)
+	if err != nil {
+		return nil, err
+	}
+
+	// the new process maps the same ports
+	if t.natMapping != nil {
+		t.natMapping.Release()
+		t.natMapping = nil
+	}
+
+	return files, nil
+}
+
+func (t *implHttpServer) Shutdown(ctx context.Context) error {
+	// the mapping is removed after in-flight requests are drained
+	defer t.closeNatMapping()
+
+	if !t.running.CAS(true, false) {
+		return nil
+	}
+
+	start := time.Now()
+
+	// clients reconnect to other instances during pre-stop delay
+	t.srv.SetKeepAlivesEnabled(false)
+	t.shutdown.preStop(ctx, t.Log, t.HealthChecker)
+
+	// closes listeners and waits for in-flight requests
+	err := t.srv.Shutdown(ctx)
+	if err != nil {
+		t.Log.Warn("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "force"), zap.Error(err))
+		t.srv.Close()
+	}
+
+	t.Log.Info("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "stopped"), zap.Duration("elapsed", time.Since(start)))
+	return err
+}
+
+func (t *implHttpServer) closeNatMapping() {
+	if t.natMapping != nil {
+		t.natMapping.Close()
+		t.natMapping = nil
+	}
+}
+
 func (t *implHttpServer) Active() bool {
 	return t.running.Load()
 }
@@ -65,13 +142,14 @@ func (t *implHttpServer) ListenAddress() net.Addr {
 	}
 }
 
+func (t *implHttpServer) ListenAddresses() []net.Addr {
+	return listenerAddrs(t.tcpListeners)
+}
+
 func (t *implHttpServer) Stop() {
-	if t.running.CAS(true, false) {
-		if t.listener != nil {
-			t.listener.Close()
-		}
-		t.srv.Close()
-	}
+	ctx, cancel := t.shutdown.context()
+	defer cancel()
+	t.Shutdown(ctx)
 }
 
 func (t *implHttpServer) Destroy() error {
@@ -94,7 +172,7 @@ func (t *implHttpServer) Serve() (err error) {
 		}
 	}()
 
-	t.Log.Info("HttpServerServe", zap.String("addr", t.srv.Addr), zap.Bool("tls", t.srv.TLSConfig != nil))
+	t.Log.Info("HttpServerServe", zap.Strings("addrs", AddrStrings(t.ListenAddresses())), zap.Bool("tls", t.srv.TLSConfig != nil))
 
 	t.running.Store(true)
 	if t.srv.TLSConfig != nil {
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	rt "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/acme/autocert"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -39,6 +41,10 @@ type implHttpServerFactory struct {
 	Resources        []*glue.ResourceSource            `inject:"optional"`
 	AutocertManager  *autocert.Manager                   `inject:"optional"`
 	TlsConfig           *tls.Config                      `inject:"optional"`
+	MetricsRegistry     api.MetricsRegistry              `inject:"optional"`
+	TracingService      api.TracingService               `inject:"optional"`
+	NodeService         sprint.NodeService               `inject:"optional"`
+	Middlewares         []api.HttpMiddleware             `inject:"optional"`
 
 	beanName     string
 }
@@ -76,9 +82,16 @@ func (t *implHttpServerFactory) Object() (object interface{}, err error) {
 
 	mux := http.NewServeMux()
 
+	tracing := t.TracingService != nil && t.TracingService.Enabled()
+
 	if options["gateway"] {
 
-		api := rt.NewServeMux(
+		var muxOpts []rt.ServeMuxOption
+		if tracing {
+			muxOpts = append(muxOpts, rt.WithMetadata(gatewayTracingMetadata))
+		}
+
+		muxOpts = append(muxOpts,
 			rt.WithMarshalerOption(runtime.MIMEWildcard, &rt.JSONPb{
 				MarshalOptions: protojson.MarshalOptions{
 					AllowPartial: t.isEnabled("allow-partial"),
@@ -93,6 +106,8 @@ func (t *implHttpServerFactory) Object() (object interface{}, err error) {
 			}),
 		)
 
+		api := rt.NewServeMux(muxOpts...)
+
 		// reserve handler for API
 		mux.Handle("/api/", api)
 	}
@@ -101,6 +116,13 @@ func (t *implHttpServerFactory) Object() (object interface{}, err error) {
 		mux.Handle("/.well-known/acme-challenge/", t.AutocertManager.HTTPHandler(nil))
 	}
 
+	if options["metrics"] {
+		if t.MetricsRegistry == nil {
+			return nil, errors.Errorf("metrics registry not found for the 'metrics' option of server '%s'", t.beanName)
+		}
+		mux.Handle("/metrics", promhttp.HandlerFor(t.MetricsRegistry, promhttp.HandlerOpts{}))
+	}
+
 	visitedPatterns := make(map[string]bool)
 
 	var pageList []string
@@ -133,17 +155,46 @@ func (t *implHttpServerFactory) Object() (object interface{}, err error) {
 	writeTimeout := t.Properties.GetDuration(fmt.Sprintf("%s.%s", t.beanName, "write-timeout"), 30 * time.Second)
 	idleTimeout := t.Properties.GetDuration(fmt.Sprintf("%s.%s", t.beanName, "idle-timeout"), time.Minute)
 
+	middlewares, err := builtinMiddlewares(t.Properties, t.beanName, options, t.Log, t.NodeService)
+	if err != nil {
+		return nil, err
+	}
+	middlewares = append(middlewares, t.Middlewares...)
+
+	var handler http.Handler = mux
+	var middlewareList []string
+	if len(middlewares) > 0 {
+		handler = newMiddlewareChain(t.beanName, mux, middlewares)
+		for _, m := range middlewares {
+			middlewareList = append(middlewareList, middlewareName(m))
+		}
+	}
+
 	t.Log.Info("HTTPServerFactory",
 		zap.String("listenAddr", listenAddr),
 		zap.Strings("pages", pageList),
 		zap.Strings("assets", assetList),
+		zap.Strings("middlewares", middlewareList),
 		zap.Any("options", options),
 		zap.Bool("tls", t.TlsConfig != nil),
+		zap.Bool("tracing", tracing),
 		zap.Bool("autocert", t.AutocertManager != nil))
 
+	if t.MetricsRegistry != nil {
+		metrics, err := newServerMetrics(t.MetricsRegistry)
+		if err != nil {
+			return nil, err
+		}
+		handler = newHttpMetricsHandler(t.beanName, metrics, handler)
+	}
+
+	if tracing {
+		handler = newHttpTracingHandler(t.beanName, handler)
+	}
+
 	srv := &http.Server{
 		Addr: listenAddr,
-		Handler: mux,
+		Handler: handler,
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 		IdleTimeout: idleTimeout,
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+const defaultListenNetwork = "tcp4"
+
+/**
+	Gets the network of the server from '<server>.listen-network' property, one of tcp, tcp4, tcp6.
+*/
+
+func listenNetwork(properties glue.Properties, beanName string) (string, error) {
+	network := strings.ToLower(properties.GetString(fmt.Sprintf("%s.%s", beanName, "listen-network"), defaultListenNetwork))
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return network, nil
+	default:
+		return "", errors.Errorf("unsupported network '%s' in property '%s.listen-network', expected tcp, tcp4 or tcp6", network, beanName)
+	}
+}
+
+/**
+	Splits the listen address property, multiple addresses are separated by ';'.
+*/
+
+func listenAddresses(value string) []string {
+	var list []string
+	for _, addr := range strings.Split(value, ";") {
+		addr = strings.TrimSpace(addr)
+		if addr != "" {
+			list = append(list, addr)
+		}
+	}
+	return list
+}
+
+/**
+	Listens all addresses, closes already bound listeners on error.
+*/
+
+func listenAll(network string, addrs []string) ([]net.Listener, error) {
+
+	if len(addrs) == 0 {
+		return nil, errors.New("empty listen address")
+	}
+
+	var list []net.Listener
+	for _, addr := range addrs {
+		l, err := net.Listen(network, addr)
+		if err != nil {
+			for _, prev := range list {
+				prev.Close()
+			}
+			return nil, errors.Errorf("can not bind to '%s' on %s, %v", addr, network, err)
+		}
+		list = append(list, l)
+	}
+
+	return list, nil
+}
+
+/**
+	Returns the single listener that accepts connections from all of them.
+*/
+
+func mergeListeners(list []net.Listener) net.Listener {
+	if len(list) == 1 {
+		return list[0]
+	}
+	return newMultiListener(list)
+}
+
+/**
+	Returns addresses of the listeners in the order of binding.
+*/
+
+func listenerAddrs(list []net.Listener) []net.Addr {
+	var addrs []net.Addr
+	for _, l := range list {
+		addrs = append(addrs, l.Addr())
+	}
+	return addrs
+}
+
+/**
+	Formats the addresses for logging.
+*/
+
+func AddrStrings(addrs []net.Addr) []string {
+	var list []string
+	for _, addr := range addrs {
+		list = append(list, addr.String())
+	}
+	return list
+}
+
+/**
+	Duplicates file descriptors of the bound listeners, unix socket files are kept on close, because they are owned by the new process.
+*/
+
+func listenerFiles(name string, list []net.Listener) ([]api.ListenerFile, error) {
+
+	var files []api.ListenerFile
+	for _, l := range list {
+
+		var file *os.File
+		var err error
+
+		switch v := l.(type) {
+		case *net.TCPListener:
+			file, err = v.File()
+		case *net.UnixListener:
+			v.SetUnlinkOnClose(false)
+			file, err = v.File()
+		default:
+			err = errors.Errorf("unsupported listener type %T", l)
+		}
+
+		if err != nil {
+			for _, f := range files {
+				f.File.Close()
+			}
+			return nil, errors.Errorf("can not get file of listener '%s' on %s, %v", name, l.Addr(), err)
+		}
+
+		files = append(files, api.ListenerFile{Name: name, File: file})
+	}
+
+	return files, nil
+}
+
+type acceptResult struct {
+	conn  net.Conn
+	err   error
+}
+
+type multiListener struct {
+	listeners  []net.Listener
+	acceptCh   chan acceptResult
+	closeCh    chan struct{}
+	closeOnce  sync.Once
+}
+
+func newMultiListener(listeners []net.Listener) *multiListener {
+	t := &multiListener{
+		listeners: listeners,
+		acceptCh:  make(chan acceptResult),
+		closeCh:   make(chan struct{}),
+	}
+	for _, l := range listeners {
+		go t.serve(l)
+	}
+	return t
+}
+
+func (t *multiListener) serve(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		select {
+		case t.acceptCh <- acceptResult{conn, err}:
+		case <-t.closeCh:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err != nil {
+			if ne, ok := err.(net.Error); !ok || !ne.Temporary() {
+				return
+			}
+		}
+	}
+}
+
+func (t *multiListener) Accept() (net.Conn, error) {
+	select {
+	case r := <-t.acceptCh:
+		return r.conn, r.err
+	case <-t.closeCh:
+		return nil, net.ErrClosed
+	}
+}
+
+func (t *multiListener) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closeCh)
+		for _, l := range t.listeners {
+			if e := l.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	})
+	return err
+}
+
+// Addr returns the address of the first listener, Addrs returns all of them
+func (t *multiListener) Addr() net.Addr {
+	return t.listeners[0].Addr()
+}
+
+func (t *multiListener) Addrs() []net.Addr {
+	return listenerAddrs(t.listeners)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+func TestListenAddresses(t *testing.T) {
+	require.Equal(t, []string{":8443", "[::]:8443"}, listenAddresses(" :8443; [::]:8443;"))
+	require.Nil(t, listenAddresses(""))
+}
+
+func TestMultiListener(t *testing.T) {
+
+	list, err := listenAll("tcp4", []string{"127.0.0.1:0", "127.0.0.1:0"})
+	require.NoError(t, err)
+
+	l := mergeListeners(list)
+
+	ml, ok := l.(*multiListener)
+	require.True(t, ok)
+	require.Equal(t, 2, len(ml.Addrs()))
+	require.Equal(t, listenerAddrs(list), ml.Addrs())
+	require.Equal(t, ml.Addrs()[0], ml.Addr())
+	require.Equal(t, []string{list[0].Addr().String(), list[1].Addr().String()}, AddrStrings(ml.Addrs()))
+
+	for _, addr := range ml.Addrs() {
+		conn, err := net.Dial("tcp4", addr.String())
+		require.NoError(t, err)
+
+		accepted, err := l.Accept()
+		require.NoError(t, err)
+		require.Equal(t, conn.LocalAddr().String(), accepted.RemoteAddr().String())
+
+		accepted.Close()
+		conn.Close()
+	}
+
+	require.NoError(t, l.Close())
+
+	_, err = l.Accept()
+	require.Error(t, err)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/**
+Request metrics of the servers, registered in the metrics registry that keeps the single instance shared by all servers,
+the servers are distinguished by the 'server' label.
+*/
+
+type serverMetrics struct {
+	grpcHandledTotal       *prometheus.CounterVec
+	grpcHandlingSeconds    *prometheus.HistogramVec
+	httpRequestsTotal      *prometheus.CounterVec
+	httpDurationSeconds    *prometheus.HistogramVec
+}
+
+func newServerMetrics(registry api.MetricsRegistry) (t *serverMetrics, err error) {
+
+	t = &serverMetrics{}
+
+	t.grpcHandledTotal, err = registerCounterVec(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"server", "grpc_type", "grpc_service", "grpc_method", "grpc_code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	t.grpcHandlingSeconds, err = registerHistogramVec(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"server", "grpc_type", "grpc_service", "grpc_method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	t.httpRequestsTotal, err = registerCounterVec(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests handled by the server.",
+	}, []string{"server", "method", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	t.httpDurationSeconds, err = registerHistogramVec(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Histogram of response latency of HTTP requests handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"server", "method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func registerCounterVec(registry api.MetricsRegistry, c *prometheus.CounterVec) (*prometheus.CounterVec, error) {
+	existing, err := registry.RegisterOrGet(c)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := existing.(*prometheus.CounterVec); ok {
+		return v, nil
+	}
+	return nil, errors.Errorf("collector %T is registered instead of the counter", existing)
+}
+
+func registerHistogramVec(registry api.MetricsRegistry, c *prometheus.HistogramVec) (*prometheus.HistogramVec, error) {
+	existing, err := registry.RegisterOrGet(c)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := existing.(*prometheus.HistogramVec); ok {
+		return v, nil
+	}
+	return nil, errors.Errorf("collector %T is registered instead of the histogram", existing)
+}
+
+/**
+Counts RPCs and observes their latency, placed before the authentication to count rejected calls too.
+*/
+
+type grpcMetricsInterceptor struct {
+	server   string
+	metrics  *serverMetrics
+}
+
+func (t grpcMetricsInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	t.observe("unary", info.FullMethod, start, err)
+	return resp, err
+}
+
+func (t grpcMetricsInterceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	t.observe(streamType(info), info.FullMethod, start, err)
+	return err
+}
+
+func (t grpcMetricsInterceptor) observe(typ, fullMethod string, start time.Time, err error) {
+	service, method := splitMethodName(fullMethod)
+	t.metrics.grpcHandledTotal.WithLabelValues(t.server, typ, service, method, status.Code(err).String()).Inc()
+	t.metrics.grpcHandlingSeconds.WithLabelValues(t.server, typ, service, method).Observe(time.Since(start).Seconds())
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+/**
+Counts HTTP requests and observes their latency, keeps the original mux reachable for the gateway lookup.
+*/
+
+type httpMetricsHandler struct {
+	mux           http.Handler
+	instrumented  http.Handler
+}
+
+func newHttpMetricsHandler(server string, metrics *serverMetrics, mux http.Handler) *httpMetricsHandler {
+	labels := prometheus.Labels{"server": server}
+	return &httpMetricsHandler{
+		mux: mux,
+		instrumented: promhttp.InstrumentHandlerCounter(metrics.httpRequestsTotal.MustCurryWith(labels),
+			promhttp.InstrumentHandlerDuration(metrics.httpDurationSeconds.MustCurryWith(labels), mux)),
+	}
+}
+
+func (t *httpMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.instrumented.ServeHTTP(w, r)
+}
+
+func (t *httpMetricsHandler) Unwrap() http.Handler {
+	return t.mux
+}
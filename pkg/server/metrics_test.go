@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"github.com/codeallergy/glue"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubMetricsRegistry struct {
+	*prometheus.Registry
+}
+
+func newStubMetricsRegistry() *stubMetricsRegistry {
+	return &stubMetricsRegistry{Registry: prometheus.NewRegistry()}
+}
+
+func (t *stubMetricsRegistry) PostConstruct() error {
+	return nil
+}
+
+func (t *stubMetricsRegistry) RegisterOrGet(c prometheus.Collector) (prometheus.Collector, error) {
+	if err := t.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (t *stubMetricsRegistry) ObserveJobRun(name string, start time.Time, err error) {
+}
+
+func (t *stubMetricsRegistry) ObserveStorageCommand(storage, command string, start time.Time, err error) {
+}
+
+func TestServerMetricsShared(t *testing.T) {
+
+	registry := newStubMetricsRegistry()
+
+	first, err := newServerMetrics(registry)
+	require.NoError(t, err)
+
+	second, err := newServerMetrics(registry)
+	require.NoError(t, err)
+
+	require.True(t, first.grpcHandledTotal == second.grpcHandledTotal)
+	require.True(t, first.httpRequestsTotal == second.httpRequestsTotal)
+}
+
+func TestGrpcMetricsInterceptor(t *testing.T) {
+
+	metrics, err := newServerMetrics(newStubMetricsRegistry())
+	require.NoError(t, err)
+
+	interceptor := grpcMetricsInterceptor{server: "control-grpc-server", metrics: metrics}
+
+	unary := &grpc.UnaryServerInfo{FullMethod: "/cert.CertService/GetZone"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "zone", nil
+	}
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "zone not found")
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = interceptor.Unary(context.Background(), nil, unary, ok)
+		require.NoError(t, err)
+	}
+	_, err = interceptor.Unary(context.Background(), nil, unary, notFound)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.grpcHandledTotal.WithLabelValues("control-grpc-server", "unary", "cert.CertService", "GetZone", "OK")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.grpcHandledTotal.WithLabelValues("control-grpc-server", "unary", "cert.CertService", "GetZone", "NotFound")))
+
+	stream := &grpc.StreamServerInfo{FullMethod: "/control.ControlService/Console", IsClientStream: true, IsServerStream: true}
+	err = interceptor.Stream(nil, nil, stream, func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.grpcHandledTotal.WithLabelValues("control-grpc-server", "bidi_stream", "control.ControlService", "Console", "OK")))
+	require.Equal(t, 2, testutil.CollectAndCount(metrics.grpcHandlingSeconds))
+}
+
+func TestHttpMetricsHandler(t *testing.T) {
+
+	metrics, err := newServerMetrics(newStubMetricsRegistry())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	handler := newHttpMetricsHandler("http-server", metrics, mux)
+	require.True(t, handler.Unwrap() == mux)
+
+	for _, path := range []string{"/ok", "/ok", "/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.httpRequestsTotal.WithLabelValues("http-server", "get", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.httpRequestsTotal.WithLabelValues("http-server", "get", "404")))
+	require.Equal(t, 1, testutil.CollectAndCount(metrics.httpDurationSeconds))
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+
+	registry := newStubMetricsRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "custom_total", Help: "Custom counter."}))
+
+	properties := glue.NewProperties()
+	properties.Set("http-server.listen-address", "127.0.0.1:0")
+	properties.Set("http-server.options", "metrics")
+
+	factory := &implHttpServerFactory{
+		Log:             zap.NewNop(),
+		Properties:      properties,
+		MetricsRegistry: registry,
+		beanName:        "http-server",
+	}
+
+	object, err := factory.Object()
+	require.NoError(t, err)
+	srv := object.(*http.Server)
+
+	// the first request is counted before the second one gathers the registry
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		body, err := ioutil.ReadAll(w.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), "custom_total 0")
+		if i > 0 {
+			require.True(t, strings.Contains(string(body), `http_requests_total{code="200",method="get",server="http-server"} 1`), string(body))
+		}
+	}
+}
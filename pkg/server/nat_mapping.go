@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNatLifetime = 20 * time.Minute
+	minNatLifetime     = time.Minute
+	natRetryInterval   = time.Minute
+)
+
+/**
+	Port mapping of the bound server through the NAT service, enabled by '<server>.nat-map' property.
+	The lease is refreshed in the half of the lifetime and removed on close.
+*/
+
+type natMapping struct {
+	nat       sprint.NatService
+	log       *zap.Logger
+
+	name      string
+	extPort   int
+	intPort   int
+	lifetime  time.Duration
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newNatMapping(properties glue.Properties, nat sprint.NatService, log *zap.Logger, beanName string, addrs []net.Addr) (*natMapping, error) {
+
+	if !properties.GetBool(fmt.Sprintf("%s.%s", beanName, "nat-map"), false) {
+		return nil, nil
+	}
+
+	if nat == nil {
+		return nil, errors.Errorf("property '%s.nat-map' is enabled, but NAT service not found", beanName)
+	}
+
+	if !nat.AllowMapping() {
+		log.Warn("NatMappingNotAllowed", zap.String("server", beanName), zap.String("nat", nat.ServiceName()))
+		return nil, nil
+	}
+
+	tcpAddr, err := natAddress(properties, beanName, addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime := properties.GetDuration(fmt.Sprintf("%s.%s", beanName, "nat-lifetime"), defaultNatLifetime)
+	if lifetime <= 0 {
+		return nil, errors.Errorf("property '%s.nat-lifetime' must be positive, got '%s'", beanName, lifetime)
+	}
+
+	// the lease is refreshed in the half of the lifetime, short lifetime would flood the gateway
+	if lifetime < minNatLifetime {
+		log.Warn("NatMappingLifetime", zap.String("server", beanName), zap.Duration("lifetime", lifetime), zap.Duration("min", minNatLifetime))
+		lifetime = minNatLifetime
+	}
+
+	m := &natMapping{
+		nat:      nat,
+		log:      log,
+		name:     beanName,
+		extPort:  properties.GetInt(fmt.Sprintf("%s.%s", beanName, "nat-external-port"), tcpAddr.Port),
+		intPort:  tcpAddr.Port,
+		lifetime: lifetime,
+		closeCh:  make(chan struct{}),
+	}
+
+	if err := m.add(); err != nil {
+		return nil, err
+	}
+
+	m.wg.Add(1)
+	go m.refresh()
+	return m, nil
+}
+
+/**
+	Selects the listener address to map, the one matching '<server>.nat-listen-address' property or the first TCP address.
+*/
+
+func natAddress(properties glue.Properties, beanName string, addrs []net.Addr) (*net.TCPAddr, error) {
+
+	key := fmt.Sprintf("%s.%s", beanName, "nat-listen-address")
+	listenAddr := properties.GetString(key, "")
+
+	for _, addr := range addrs {
+		tcpAddr, ok := addr.(*net.TCPAddr)
+		if !ok {
+			continue
+		}
+		if listenAddr == "" || matchListenAddress(tcpAddr, []string{listenAddr}) {
+			return tcpAddr, nil
+		}
+	}
+
+	if listenAddr != "" {
+		return nil, errors.Errorf("server '%s' does not listen '%s' from property '%s', listen addresses %v", beanName, listenAddr, key, addrs)
+	}
+	return nil, errors.Errorf("server '%s' does not have TCP listen address in %v", beanName, addrs)
+}
+
+func (t *natMapping) add() error {
+	err := t.nat.AddMapping("tcp", t.extPort, t.intPort, t.name, t.lifetime)
+	if err != nil {
+		return errors.Wrapf(err, "add NAT mapping %d->%d for server '%s' by %s", t.extPort, t.intPort, t.name, t.nat.ServiceName())
+	}
+	t.log.Info("NatMapping", zap.String("server", t.name), zap.String("nat", t.nat.ServiceName()), zap.Int("extPort", t.extPort), zap.Int("intPort", t.intPort), zap.Duration("lifetime", t.lifetime))
+	return nil
+}
+
+func (t *natMapping) refresh() {
+	defer t.wg.Done()
+
+	timer := time.NewTimer(t.lifetime / 2)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.closeCh:
+			return
+		case <-timer.C:
+			if err := t.add(); err != nil {
+				t.log.Error("NatMappingRefresh", zap.String("server", t.name), zap.Error(err))
+				timer.Reset(natRetryInterval)
+			} else {
+				timer.Reset(t.lifetime / 2)
+			}
+		}
+	}
+}
+
+/**
+	Stops the lease refresh, but keeps the mapping that is taken over by the new process on graceful restart.
+*/
+
+func (t *natMapping) Release() {
+	t.closeOnce.Do(func() {
+		close(t.closeCh)
+		t.wg.Wait()
+	})
+}
+
+func (t *natMapping) Close() {
+	t.closeOnce.Do(func() {
+		close(t.closeCh)
+		t.wg.Wait()
+		if err := t.nat.DeleteMapping("tcp", t.extPort, t.intPort); err != nil {
+			t.log.Error("NatMappingDelete", zap.String("server", t.name), zap.Int("extPort", t.extPort), zap.Error(err))
+		} else {
+			t.log.Info("NatMappingDelete", zap.String("server", t.name), zap.Int("extPort", t.extPort), zap.Int("intPort", t.intPort))
+		}
+	})
+}
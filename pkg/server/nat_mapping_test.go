@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type stubNatService struct {
+	sprint.NatService
+
+	mu       sync.Mutex
+	adds     []time.Duration
+	deletes  int
+	addErr   error
+	onDelete func()
+}
+
+func (t *stubNatService) AllowMapping() bool {
+	return true
+}
+
+func (t *stubNatService) ServiceName() string {
+	return "stub"
+}
+
+func (t *stubNatService) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.adds = append(t.adds, lifetime)
+	return t.addErr
+}
+
+func (t *stubNatService) DeleteMapping(protocol string, extport, intport int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deletes++
+	if t.onDelete != nil {
+		t.onDelete()
+	}
+	return nil
+}
+
+func (t *stubNatService) counts() (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.adds), t.deletes
+}
+
+func natProperties(lifetime string) glue.Properties {
+	properties := glue.NewProperties()
+	properties.Set("test-server.nat-map", "true")
+	if lifetime != "" {
+		properties.Set("test-server.nat-lifetime", lifetime)
+	}
+	return properties
+}
+
+func TestNatMappingLifetime(t *testing.T) {
+
+	addr := &net.TCPAddr{IP: net.IPv4zero, Port: 8443}
+
+	for _, lifetime := range []string{"0s", "-1m"} {
+		nat := &stubNatService{}
+		_, err := newNatMapping(natProperties(lifetime), nat, zap.NewNop(), "test-server", []net.Addr{addr})
+		require.Error(t, err, lifetime)
+		adds, _ := nat.counts()
+		require.Equal(t, 0, adds)
+	}
+
+	nat := &stubNatService{}
+	m, err := newNatMapping(natProperties("1ns"), nat, zap.NewNop(), "test-server", []net.Addr{addr})
+	require.NoError(t, err)
+	require.Equal(t, minNatLifetime, m.lifetime)
+	m.Close()
+	require.Equal(t, []time.Duration{minNatLifetime}, nat.adds)
+
+	m, err = newNatMapping(natProperties(""), &stubNatService{}, zap.NewNop(), "test-server", []net.Addr{addr})
+	require.NoError(t, err)
+	require.Equal(t, defaultNatLifetime, m.lifetime)
+	m.Release()
+
+	m, err = newNatMapping(glue.NewProperties(), &stubNatService{}, zap.NewNop(), "test-server", []net.Addr{addr})
+	require.NoError(t, err)
+	require.Nil(t, m)
+}
+
+func TestNatAddress(t *testing.T) {
+
+	unix := &net.UnixAddr{Name: "/run/test.sock", Net: "unix"}
+	first := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8443}
+	second := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 8444}
+	addrs := []net.Addr{unix, first, second}
+
+	addr, err := natAddress(glue.NewProperties(), "test-server", addrs)
+	require.NoError(t, err)
+	require.Equal(t, first, addr)
+
+	properties := glue.NewProperties()
+	properties.Set("test-server.nat-listen-address", "10.0.0.1:8444")
+	addr, err = natAddress(properties, "test-server", addrs)
+	require.NoError(t, err)
+	require.Equal(t, second, addr)
+
+	properties.Set("test-server.nat-listen-address", ":9000")
+	_, err = natAddress(properties, "test-server", addrs)
+	require.Error(t, err)
+
+	_, err = natAddress(glue.NewProperties(), "test-server", []net.Addr{unix})
+	require.Error(t, err)
+}
+
+func TestNatMappingAddError(t *testing.T) {
+
+	nat := &stubNatService{addErr: errors.New("gateway refused")}
+	_, err := newNatMapping(natProperties(""), nat, zap.NewNop(), "test-server", []net.Addr{&net.TCPAddr{Port: 8443}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "gateway refused")
+}
+
+func newTestNatMapping(nat *stubNatService, lifetime time.Duration) *natMapping {
+	m := &natMapping{
+		nat:      nat,
+		log:      zap.NewNop(),
+		name:     "test-server",
+		extPort:  8443,
+		intPort:  8443,
+		lifetime: lifetime,
+		closeCh:  make(chan struct{}),
+	}
+	m.wg.Add(1)
+	go m.refresh()
+	return m
+}
+
+func TestNatMappingRefreshClose(t *testing.T) {
+
+	nat := &stubNatService{}
+	m := newTestNatMapping(nat, 20 * time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		adds, _ := nat.counts()
+		return adds >= 3
+	}, time.Second, 5 * time.Millisecond)
+
+	m.Close()
+	m.Close()
+
+	adds, deletes := nat.counts()
+	require.Equal(t, 1, deletes)
+
+	time.Sleep(30 * time.Millisecond)
+	after, _ := nat.counts()
+	require.Equal(t, adds, after)
+}
+
+func TestNatMappingRelease(t *testing.T) {
+
+	nat := &stubNatService{}
+	m := newTestNatMapping(nat, 20 * time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		adds, _ := nat.counts()
+		return adds >= 1
+	}, time.Second, 5 * time.Millisecond)
+
+	m.Release()
+	m.Close()
+
+	adds, deletes := nat.counts()
+	require.Equal(t, 0, deletes)
+
+	time.Sleep(30 * time.Millisecond)
+	after, _ := nat.counts()
+	require.Equal(t, adds, after)
+}
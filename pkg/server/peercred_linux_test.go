@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/stretchr/testify/require"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnixPeerInfo(t *testing.T) {
+
+	socketPath := filepath.Join(t.TempDir(), "control.sock")
+
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	accepted, err := l.Accept()
+	require.NoError(t, err)
+	defer accepted.Close()
+
+	info, err := getPeerInfo(accepted.(*net.UnixConn))
+	require.NoError(t, err)
+	require.Equal(t, os.Getuid(), info.Uid)
+	require.Equal(t, os.Getpid(), info.Pid)
+	require.Equal(t, "unix", info.AuthType())
+}
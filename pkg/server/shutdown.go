@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/codeallergy/glue"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"time"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+/**
+	Shutdown settings of the server from '<server>.shutdown-timeout' and '<server>.pre-stop-delay' properties.
+*/
+
+type shutdownConfig struct {
+	beanName      string
+	timeout       time.Duration
+	preStopDelay  time.Duration
+}
+
+func newShutdownConfig(properties glue.Properties, beanName string) shutdownConfig {
+	return shutdownConfig{
+		beanName:     beanName,
+		timeout:      properties.GetDuration(fmt.Sprintf("%s.%s", beanName, "shutdown-timeout"), defaultShutdownTimeout),
+		preStopDelay: properties.GetDuration(fmt.Sprintf("%s.%s", beanName, "pre-stop-delay"), 0),
+	}
+}
+
+/**
+	Context of the whole shutdown sequence, includes the pre-stop delay.
+*/
+
+func (t shutdownConfig) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), t.preStopDelay + t.timeout)
+}
+
+/**
+	Reports NOT_SERVING to health checks and waits the pre-stop delay, so load balancers stop sending new requests while the listener is open.
+*/
+
+func (t shutdownConfig) preStop(ctx context.Context, log *zap.Logger, healthChecker *health.Server) {
+
+	if healthChecker != nil {
+		healthChecker.Shutdown()
+		log.Info("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "not-serving"))
+	}
+
+	if t.preStopDelay > 0 {
+		log.Info("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "pre-stop"), zap.Duration("delay", t.preStopDelay))
+		timer := time.NewTimer(t.preStopDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	log.Info("ServerShutdown", zap.String("server", t.beanName), zap.String("step", "drain"), zap.Duration("timeout", t.timeout))
+}
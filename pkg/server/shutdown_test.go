@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"github.com/codeallergy/glue"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHttpServerGracefulStop(t *testing.T) {
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		finished.Store(true)
+		w.Write([]byte("done"))
+	})
+
+	properties := glue.NewProperties()
+	properties.Set("test-server.shutdown-timeout", "5s")
+	properties.Set("test-server.nat-map", "true")
+
+	// the port stays mapped until in-flight requests are drained
+	var drainedBeforeDelete bool
+	nat := &stubNatService{onDelete: func() {
+		drainedBeforeDelete = finished.Load()
+	}}
+
+	srv := &implHttpServer{
+		Properties: properties,
+		NatService: nat,
+		Log:        zap.NewNop(),
+		beanName:   "test-server",
+		srv:        &http.Server{Addr: "127.0.0.1:0", Handler: mux},
+	}
+	require.NoError(t, srv.PostConstruct())
+	require.NoError(t, srv.Bind())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve()
+	}()
+
+	type result struct {
+		body string
+		err  error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + srv.ListenAddress().String() + "/slow")
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		response <- result{string(body), err}
+	}()
+
+	<-started
+	srv.Stop()
+
+	r := <-response
+	require.NoError(t, r.err)
+	require.Equal(t, "done", r.body)
+	require.NoError(t, <-served)
+	require.False(t, srv.Active())
+
+	_, deletes := nat.counts()
+	require.Equal(t, 1, deletes)
+	require.True(t, drainedBeforeDelete)
+	require.Nil(t, srv.natMapping)
+}
@@ -17,13 +17,21 @@ package server
 import (
 	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"github.com/codeallergy/glue"
 	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/api"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"go.uber.org/zap"
 	"reflect"
 	"github.com/pkg/errors"
+	"sync"
+	"time"
 )
 
+const defaultClientCARefresh = time.Minute
+
 type implTlsConfigFactory struct {
 
 	Properties  glue.Properties `inject`
@@ -31,6 +39,8 @@ type implTlsConfigFactory struct {
 
 	CertificateManager sprint.CertificateManager `inject`
 	DomainService      sprint.CertificateService `inject`
+	CertificateRepository sprint.CertificateRepository `inject`
+	Log                *zap.Logger               `inject`
 
 	beanName          string
 }
@@ -62,10 +72,119 @@ func (t *implTlsConfigFactory) Object() (obj interface{}, err error) {
 		InsecureSkipVerify: insecure,
 	}
 
+	clientAuth := t.Properties.GetString(fmt.Sprintf("%s.client-auth", t.beanName), "")
+	if clientAuth != "" {
+		tlsConfig.ClientAuth = util.ParseClientAuth(clientAuth)
+	}
+
 	tlsConfig.NextProtos = AppendH2ToNextProtos(tlsConfig.NextProtos)
+
+	if tlsConfig.ClientAuth >= tls.VerifyClientCertIfGiven {
+		signer := t.Properties.GetString(fmt.Sprintf("%s.client-ca", t.beanName), "localhost")
+		var found bool
+		tlsConfig.ClientCAs, found, err = t.loadClientCAs(signer)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			// empty pool rejects all client certificates until the self signer would be created
+			t.Log.Warn("ClientCANotFound", zap.String("signer", signer))
+		}
+		cache := &clientCAConfig{
+			base:     tlsConfig.Clone(),
+			refresh:  t.Properties.GetDuration(fmt.Sprintf("%s.client-ca-refresh", t.beanName), defaultClientCARefresh),
+			load: func() (*x509.CertPool, bool, error) {
+				return t.loadClientCAs(signer)
+			},
+			signer:   signer,
+			log:      t.Log,
+		}
+		cache.update(tlsConfig.ClientCAs, time.Now())
+		tlsConfig.GetConfigForClient = cache.configForClient
+	}
+
 	return tlsConfig, nil
 }
 
+/**
+Caches the config with client CAs for the refresh interval, so the self signer created or rotated after the start
+is trusted without restart and handshakes do not read the storage.
+*/
+
+type clientCAConfig struct {
+	base      *tls.Config
+	refresh   time.Duration
+	load      func() (*x509.CertPool, bool, error)
+	signer    string
+	log       *zap.Logger
+
+	mu        sync.Mutex
+	config    *tls.Config
+	loadedAt  time.Time
+}
+
+func (t *clientCAConfig) update(pool *x509.CertPool, now time.Time) {
+	config := t.base.Clone()
+	config.ClientCAs = pool
+	t.config = config
+	t.loadedAt = now
+}
+
+func (t *clientCAConfig) configForClient(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.loadedAt) < t.refresh {
+		return t.config, nil
+	}
+
+	pool, _, err := t.load()
+	if err != nil {
+		// keep the previous pool and retry after the refresh interval
+		t.log.Error("ClientCALoad", zap.String("signer", t.signer), zap.Error(err))
+		t.loadedAt = now
+		return t.config, nil
+	}
+
+	t.update(pool, now)
+	return t.config, nil
+}
+
+func (t *implTlsConfigFactory) loadClientCAs(signer string) (*x509.CertPool, bool, error) {
+
+	pool := x509.NewCertPool()
+
+	self, err := t.CertificateRepository.FindSelfSigner(signer)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "find self signer '%s'", signer)
+	}
+
+	if self.Name == "" {
+		return pool, false, nil
+	}
+
+	for i := self; i != nil; i = i.Issuer {
+		if !pool.AppendCertsFromPEM(i.Certificate) {
+			return nil, false, errors.Errorf("invalid certificate in self signer '%s'", i.Name)
+		}
+	}
+
+	// trust client certificates issued before the rotation until the previous signer is retired
+	prev, err := t.CertificateRepository.FindSelfSigner(signer + api.PreviousSignerSuffix)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "find previous self signer '%s'", signer)
+	}
+
+	for i := prev; i != nil && i.Certificate != nil; i = i.Issuer {
+		if !pool.AppendCertsFromPEM(i.Certificate) {
+			return nil, false, errors.Errorf("invalid certificate in previous self signer '%s'", prev.Name)
+		}
+	}
+
+	return pool, true, nil
+}
+
 func (t *implTlsConfigFactory) ObjectType() reflect.Type {
 	return sprint.TlsConfigClass
 }
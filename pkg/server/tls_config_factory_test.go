@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintpb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type stubSignerRepository struct {
+	sprint.CertificateRepository
+	signers map[string]*sprintpb.SelfSigner
+	finds   int
+}
+
+func (t *stubSignerRepository) FindSelfSigner(name string) (*sprintpb.SelfSigner, error) {
+	t.finds++
+	if s, ok := t.signers[name]; ok {
+		return s, nil
+	}
+	return &sprintpb.SelfSigner{}, nil
+}
+
+type stubCertificateManager struct {
+	sprint.CertificateManager
+}
+
+func (t *stubCertificateManager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return nil, nil
+}
+
+func selfSignedPEM(t *testing.T, cn string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestClientCAsCachedAndRefreshed(t *testing.T) {
+
+	properties := glue.NewProperties()
+	properties.Set("tls-config.client-auth", "verify_client_cert")
+	properties.Set("tls-config.client-ca-refresh", "100ms")
+
+	repo := &stubSignerRepository{signers: make(map[string]*sprintpb.SelfSigner)}
+	factory := &implTlsConfigFactory{
+		Properties:            properties,
+		CertificateManager:    &stubCertificateManager{},
+		CertificateRepository: repo,
+		Log:                   zap.NewNop(),
+		beanName:              "tls-config",
+	}
+
+	obj, err := factory.Object()
+	require.NoError(t, err)
+	config := obj.(*tls.Config)
+	require.NotNil(t, config.GetConfigForClient)
+	loaded := repo.finds
+
+	hello := &tls.ClientHelloInfo{}
+	before, err := config.GetConfigForClient(hello)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(before.ClientCAs.Subjects()))
+
+	// handshakes within the refresh interval do not read the storage
+	repo.signers["localhost"] = &sprintpb.SelfSigner{Name: "localhost", Certificate: selfSignedPEM(t, "root")}
+
+	cached, err := config.GetConfigForClient(hello)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(cached.ClientCAs.Subjects()))
+	require.Equal(t, loaded, repo.finds)
+
+	// self signer created after the start is trusted after the refresh
+	time.Sleep(150 * time.Millisecond)
+
+	after, err := config.GetConfigForClient(hello)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(after.ClientCAs.Subjects()))
+	require.Nil(t, after.GetConfigForClient)
+	require.Greater(t, repo.finds, loaded)
+
+	refreshed := repo.finds
+	again, err := config.GetConfigForClient(hello)
+	require.NoError(t, err)
+	require.True(t, again == after)
+	require.Equal(t, refreshed, repo.finds)
+}
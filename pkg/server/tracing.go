@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"strings"
+)
+
+/**
+Starts the server span of the RPC as a child of the span propagated in the request metadata.
+*/
+
+type grpcTracingInterceptor struct {
+	server string
+}
+
+func (t grpcTracingInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := t.start(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	t.end(span, err)
+	return resp, err
+}
+
+func (t grpcTracingInterceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := t.start(ss.Context(), info.FullMethod)
+	err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+	t.end(span, err)
+	return err
+}
+
+func (t grpcTracingInterceptor) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	service, method := splitMethodName(fullMethod)
+	return util.StartSpan(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("grpc"),
+			semconv.RPCServiceKey.String(service),
+			semconv.RPCMethodKey.String(method),
+			attribute.String("server", t.server)))
+}
+
+func (t grpcTracingInterceptor) end(span trace.Span, err error) {
+	s := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(s.Code())))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, s.Message())
+	}
+	span.End()
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+}
+
+func (t *tracedServerStream) Context() context.Context {
+	return t.ctx
+}
+
+/**
+Carrier of the propagated trace context in gRPC metadata.
+*/
+
+type metadataCarrier metadata.MD
+
+func (t metadataCarrier) Get(key string) string {
+	if values := metadata.MD(t).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (t metadataCarrier) Set(key, value string) {
+	metadata.MD(t).Set(key, value)
+}
+
+func (t metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+/**
+Passes the trace context of the HTTP request to the gRPC calls made by the gateway mux.
+*/
+
+func gatewayTracingMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return md
+}
+
+/**
+Starts the server span of the HTTP request as a child of the span propagated in the request headers.
+*/
+
+type httpTracingHandler struct {
+	server   string
+	handler  http.Handler
+}
+
+func newHttpTracingHandler(server string, handler http.Handler) *httpTracingHandler {
+	return &httpTracingHandler{server: server, handler: handler}
+}
+
+func (t *httpTracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := util.StartSpan(ctx, "HTTP "+r.Method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(t.server, "", r)...))
+	defer span.End()
+
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	t.handler.ServeHTTP(sw, r.WithContext(ctx))
+
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(sw.status)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(sw.status, trace.SpanKindServer))
+}
+
+func (t *httpTracingHandler) Unwrap() http.Handler {
+	return t.handler
+}
+
+/**
+Remembers the status code of the response, keeps streaming and hijacking available for the wrapped handler.
+*/
+
+type statusWriter struct {
+	http.ResponseWriter
+	status       int
+	written      int64
+	wroteHeader  bool
+}
+
+func (t *statusWriter) WriteHeader(code int) {
+	if !t.wroteHeader {
+		t.status = code
+		t.wroteHeader = true
+	}
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *statusWriter) Write(b []byte) (int, error) {
+	t.wroteHeader = true
+	n, err := t.ResponseWriter.Write(b)
+	t.written += int64(n)
+	return n, err
+}
+
+func (t *statusWriter) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (t *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := t.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
+
+func (t *statusWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
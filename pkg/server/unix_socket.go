@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"github.com/codeallergy/glue"
+	"github.com/codeallergy/sprint"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+/**
+	Peer credentials of the unix socket connection, taken by SO_PEERCRED.
+*/
+
+type unixPeerInfo struct {
+	credentials.CommonAuthInfo
+	Uid  int
+	Gid  int
+	Pid  int
+}
+
+func (t unixPeerInfo) AuthType() string {
+	return "unix"
+}
+
+/**
+	Gets the unix socket path from '<server>.unix-socket' property, the relative path is resolved in the run directory.
+*/
+
+func unixSocketPath(application sprint.Application, properties glue.Properties, beanName string) string {
+
+	socketFile := properties.GetString(fmt.Sprintf("%s.%s", beanName, "unix-socket"), "")
+	if socketFile == "" || filepath.IsAbs(socketFile) {
+		return socketFile
+	}
+
+	runDir := properties.GetString("application.run.dir", "")
+	if runDir == "" {
+		runDir = filepath.Join(application.ApplicationDir(), "run")
+	}
+
+	return filepath.Join(runDir, socketFile)
+}
+
+/**
+	Listens the unix socket with '<server>.unix-socket-perm' file permissions, the stale socket file is removed.
+*/
+
+func listenUnixSocket(properties glue.Properties, beanName string, socketPath string) (net.Listener, error) {
+
+	runDir := filepath.Dir(socketPath)
+	if _, err := os.Stat(runDir); err != nil {
+		runDirPerm := util.ParseFileMode(properties.GetString("application.perm.run.dir", "-rwxrwxr-x"))
+		if err = os.MkdirAll(runDir, runDirPerm); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := util.RemoveFileIfExist(socketPath); err != nil {
+		return nil, errors.Errorf("can not remove stale socket '%s', %v", socketPath, err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Errorf("can not bind to unix socket '%s', %v", socketPath, err)
+	}
+
+	perm := util.ParseFileMode(properties.GetString(fmt.Sprintf("%s.%s", beanName, "unix-socket-perm"), "-rw-rw----"))
+	if err := os.Chmod(socketPath, perm); err != nil {
+		listener.Close()
+		return nil, errors.Errorf("can not change permissions of unix socket '%s', %v", socketPath, err)
+	}
+
+	return listener, nil
+}
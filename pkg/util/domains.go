@@ -16,6 +16,9 @@ package util
 
 import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/pkg/errors"
+	"golang.org/x/net/idna"
+	"strings"
 )
 
 func ToZone(domain string) (string, error) {
@@ -46,3 +49,57 @@ func UnFqdn(name string) string {
 	}
 	return name
 }
+
+// ParseDomains splits the comma separated list of domains, converts them to punycode
+// and removes duplicates. Wildcard domains keep the "*." prefix.
+func ParseDomains(list string) ([]string, error) {
+
+	var domains []string
+	seen := make(map[string]bool)
+
+	for _, domain := range strings.Split(list, ",") {
+
+		domain = strings.ToLower(UnFqdn(strings.TrimSpace(domain)))
+		if domain == "" {
+			continue
+		}
+
+		name, wildcard := domain, strings.HasPrefix(domain, "*.")
+		if wildcard {
+			name = domain[2:]
+		}
+
+		if strings.Contains(name, "*") {
+			return nil, errors.Errorf("domain name '%s' has wildcard not in the leftmost label", domain)
+		}
+
+		punycode, err := idna.Lookup.ToASCII(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "domain name '%s' contains invalid character", domain)
+		}
+
+		if wildcard {
+			punycode = "*." + punycode
+		}
+
+		if !seen[punycode] {
+			seen[punycode] = true
+			domains = append(domains, punycode)
+		}
+	}
+
+	if len(domains) == 0 {
+		return nil, errors.Errorf("empty domain list '%s'", list)
+	}
+
+	return domains, nil
+}
+
+// WildcardOf returns the wildcard name matching the domain, the leftmost label is replaced by '*'.
+func WildcardOf(domain string) (string, bool) {
+	i := strings.IndexByte(domain, '.')
+	if i <= 0 || i == len(domain)-1 {
+		return "", false
+	}
+	return "*" + domain[i:], true
+}
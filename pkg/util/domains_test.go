@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util_test
+
+import (
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseDomains(t *testing.T) {
+
+	domains, err := util.ParseDomains("API.a.com., b.net,*.b.net,b.net,бюро.рф")
+	require.NoError(t, err)
+	require.Equal(t, []string{"api.a.com", "b.net", "*.b.net", "xn--90a0af9c.xn--p1ai"}, domains)
+
+	_, err = util.ParseDomains("a.*.com")
+	require.Error(t, err)
+
+	_, err = util.ParseDomains(" , ")
+	require.Error(t, err)
+}
+
+func TestWildcardOf(t *testing.T) {
+
+	w, ok := util.WildcardOf("api.a.com")
+	require.True(t, ok)
+	require.Equal(t, "*.a.com", w)
+
+	_, ok = util.WildcardOf("localhost")
+	require.False(t, ok)
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/pkg/errors"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+	"time"
+)
+
+var ExportFormats = []string{"pem", "fullchain", "bundle", "pkcs12", "der", "jks"}
+
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+/**
+Certificate bundle split to the private key, leaf, intermediate chain and root.
+*/
+type CertificateBundle struct {
+	Name  string
+	Key   []byte
+	Leaf  *x509.Certificate
+	Chain []*x509.Certificate
+	Root  *x509.Certificate
+}
+
+/**
+Parses PEM encoded private key, certificate and issuer certificates. The certificate
+could contain the full chain, duplicates are removed and the self signed one is the root.
+*/
+func ParseCertificateBundle(name string, keyPem, certPem, issuerPem []byte) (*CertificateBundle, error) {
+
+	b := &CertificateBundle{Name: name}
+
+	if len(keyPem) > 0 {
+		block, _ := pem.Decode(keyPem)
+		if block == nil {
+			return nil, errors.Errorf("invalid private key of '%s'", name)
+		}
+		key, err := parsePrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse private key of '%s'", name)
+		}
+		b.Key, err = x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var list []*x509.Certificate
+	seen := make(map[string]bool)
+	for _, content := range [][]byte{certPem, issuerPem} {
+		for {
+			var block *pem.Block
+			block, content = pem.Decode(content)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			if seen[string(block.Bytes)] {
+				continue
+			}
+			seen[string(block.Bytes)] = true
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse certificate of '%s'", name)
+			}
+			list = append(list, cert)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, errors.Errorf("empty certificate of '%s'", name)
+	}
+
+	b.Leaf = list[0]
+	for _, cert := range list[1:] {
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil {
+			b.Root = cert
+		} else {
+			b.Chain = append(b.Chain, cert)
+		}
+	}
+
+	return b, nil
+}
+
+func parsePrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return x509.ParseECPrivateKey(der)
+}
+
+/**
+Exports the bundle in the format, returns the content and the file extension.
+The pem format is a single file with the private key and the full chain, the bundle
+format is a tar.gz archive with the key, certificate, chain and root in separate files.
+*/
+func ExportCertificateBundle(b *CertificateBundle, format, password string) ([]byte, string, error) {
+
+	switch format {
+	case "pem":
+		return b.keyAndFullChain(), "pem", nil
+
+	case "bundle":
+		content, err := b.tarball()
+		return content, "tar.gz", err
+
+	case "fullchain":
+		return b.fullChain(), "pem", nil
+
+	case "der":
+		return b.Leaf.Raw, "der", nil
+
+	case "pkcs12":
+		key, err := b.privateKey()
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := pkcs12.Encode(rand.Reader, key, b.Leaf, b.caCerts(), password)
+		return content, "p12", err
+
+	case "jks":
+		content, err := b.keyStore(password)
+		return content, "jks", err
+
+	default:
+		return nil, "", errors.Wrap(ErrUnknownExportFormat, format)
+	}
+}
+
+func (b *CertificateBundle) caCerts() []*x509.Certificate {
+	certs := append([]*x509.Certificate{}, b.Chain...)
+	if b.Root != nil {
+		certs = append(certs, b.Root)
+	}
+	return certs
+}
+
+func (b *CertificateBundle) privateKey() (interface{}, error) {
+	if len(b.Key) == 0 {
+		return nil, errors.Errorf("empty private key of '%s'", b.Name)
+	}
+	return x509.ParsePKCS8PrivateKey(b.Key)
+}
+
+func (b *CertificateBundle) fullChain() []byte {
+	var buf bytes.Buffer
+	pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: b.Leaf.Raw})
+	buf.Write(encodeCertificates(b.Chain))
+	return buf.Bytes()
+}
+
+func (b *CertificateBundle) keyAndFullChain() []byte {
+	var buf bytes.Buffer
+	if len(b.Key) > 0 {
+		pem.Encode(&buf, &pem.Block{Type: "PRIVATE KEY", Bytes: b.Key})
+	}
+	buf.Write(b.fullChain())
+	return buf.Bytes()
+}
+
+func encodeCertificates(list []*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, cert := range list {
+		pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
+
+func (b *CertificateBundle) tarball() ([]byte, error) {
+
+	type tarEntry struct {
+		name    string
+		mode    int64
+		content []byte
+	}
+
+	var entries []tarEntry
+	if len(b.Key) > 0 {
+		entries = append(entries, tarEntry{"key.pem", 0600, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b.Key})})
+	}
+	entries = append(entries,
+		tarEntry{"cert.pem", 0644, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b.Leaf.Raw})},
+		tarEntry{"chain.pem", 0644, encodeCertificates(b.Chain)},
+		tarEntry{"fullchain.pem", 0644, b.fullChain()})
+	if b.Root != nil {
+		entries = append(entries, tarEntry{"root.pem", 0644, encodeCertificates([]*x509.Certificate{b.Root})})
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:    b.Name + "/" + e.name,
+			Mode:    e.mode,
+			Size:    int64(len(e.content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(e.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *CertificateBundle) keyStore(password string) ([]byte, error) {
+
+	if len(b.Key) == 0 {
+		return nil, errors.Errorf("empty private key of '%s'", b.Name)
+	}
+
+	if len(password) < 6 {
+		return nil, errors.New("jks password must be at least 6 characters")
+	}
+
+	chain := []keystore.Certificate{{Type: "X509", Content: b.Leaf.Raw}}
+	for _, cert := range b.caCerts() {
+		chain = append(chain, keystore.Certificate{Type: "X509", Content: cert.Raw})
+	}
+
+	now := time.Now()
+	ks := keystore.New()
+	err := ks.SetPrivateKeyEntry(b.Name, keystore.PrivateKeyEntry{
+		CreationTime:     now,
+		PrivateKey:       b.Key,
+		CertificateChain: chain,
+	}, []byte(password))
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Root != nil {
+		err = ks.SetTrustedCertificateEntry(b.Name+"-root", keystore.TrustedCertificateEntry{
+			CreationTime: now,
+			Certificate:  keystore.Certificate{Type: "X509", Content: b.Root.Raw},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(password)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/stretchr/testify/require"
+	"io"
+	"math/big"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+	"testing"
+	"time"
+)
+
+func TestExportCertificateBundle(t *testing.T) {
+
+	rootKey, root := issueTestCert(t, "root", nil, nil, true)
+	interKey, inter := issueTestCert(t, "inter", root, rootKey, true)
+	leafKey, leaf := issueTestCert(t, "example.com", inter, interKey, false)
+
+	keyDer, err := x509.MarshalECPrivateKey(leafKey)
+	require.NoError(t, err)
+
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	certPem := append(encodeTestCert(leaf), encodeTestCert(inter)...)
+	issuerPem := append(encodeTestCert(inter), encodeTestCert(root)...)
+
+	b, err := util.ParseCertificateBundle("example.com", keyPem, certPem, issuerPem)
+	require.NoError(t, err)
+	require.Equal(t, leaf.Raw, b.Leaf.Raw)
+	require.Equal(t, 1, len(b.Chain))
+	require.Equal(t, inter.Raw, b.Chain[0].Raw)
+	require.NotNil(t, b.Root)
+	require.Equal(t, root.Raw, b.Root.Raw)
+
+	content, ext, err := util.ExportCertificateBundle(b, "der", "")
+	require.NoError(t, err)
+	require.Equal(t, "der", ext)
+	require.Equal(t, leaf.Raw, content)
+
+	content, ext, err = util.ExportCertificateBundle(b, "fullchain", "")
+	require.NoError(t, err)
+	require.Equal(t, "pem", ext)
+	require.Equal(t, certPem, content)
+
+	content, ext, err = util.ExportCertificateBundle(b, "pem", "")
+	require.NoError(t, err)
+	require.Equal(t, "pem", ext)
+
+	block, rest := pem.Decode(content)
+	require.NotNil(t, block)
+	require.Equal(t, "PRIVATE KEY", block.Type)
+	require.Equal(t, certPem, rest)
+
+	content, ext, err = util.ExportCertificateBundle(b, "bundle", "")
+	require.NoError(t, err)
+	require.Equal(t, "tar.gz", ext)
+
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	require.Equal(t, []string{"example.com/key.pem", "example.com/cert.pem", "example.com/chain.pem", "example.com/fullchain.pem", "example.com/root.pem"}, names)
+
+	content, ext, err = util.ExportCertificateBundle(b, "pkcs12", "secret")
+	require.NoError(t, err)
+	require.Equal(t, "p12", ext)
+
+	_, cert, caCerts, err := pkcs12.DecodeChain(content, "secret")
+	require.NoError(t, err)
+	require.Equal(t, leaf.Raw, cert.Raw)
+	require.Equal(t, 2, len(caCerts))
+
+	_, _, err = util.ExportCertificateBundle(b, "jks", "short")
+	require.Error(t, err)
+
+	content, ext, err = util.ExportCertificateBundle(b, "jks", "changeit")
+	require.NoError(t, err)
+	require.Equal(t, "jks", ext)
+
+	ks := keystore.New()
+	require.NoError(t, ks.Load(bytes.NewReader(content), []byte("changeit")))
+	entry, err := ks.GetPrivateKeyEntry("example.com", []byte("changeit"))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entry.CertificateChain))
+	require.True(t, ks.IsTrustedCertificateEntry("example.com-root"))
+
+	_, _, err = util.ExportCertificateBundle(b, "unknown", "")
+	require.ErrorIs(t, err, util.ErrUnknownExportFormat)
+}
+
+func issueTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*ecdsa.PrivateKey, *x509.Certificate) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{cn}
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
+
+func encodeTestCert(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
@@ -21,14 +21,26 @@ import (
 	"net/url"
 )
 
+/**
+Handler that decorates the server mux, the gateway mux is searched in the unwrapped handler.
+*/
+type WrappedHandler interface {
+	Unwrap() http.Handler
+}
+
 func FindGatewayHandler(srv *http.Server, pattern string) (*rt.ServeMux, error) {
-	handler := srv.Handler
+	return findGatewayHandler(srv.Handler, pattern)
+}
+
+func findGatewayHandler(handler http.Handler, pattern string) (*rt.ServeMux, error) {
 
 	switch mux := handler.(type) {
 	case *rt.ServeMux:
 		return mux, nil
 	case *http.ServeMux:
 		return findGatewayAPIHandler(mux, pattern)
+	case WrappedHandler:
+		return findGatewayHandler(mux.Unwrap(), pattern)
 	default:
 		return nil, errors.Errorf("unknown server handler '%v'", handler)
 	}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// RestartReadySocketEnv is the socket of the parent process waiting for the readiness on graceful restart
+const RestartReadySocketEnv = "SPRINT_READY_SOCKET"
+
+/**
+Sends the state notification to the systemd service manager, returns false if the process was not started by systemd with NOTIFY_SOCKET.
+*/
+func SdNotify(state string) (bool, error) {
+	return notifySocket(os.Getenv("NOTIFY_SOCKET"), state)
+}
+
+// Notifications of the new process sent to the parent process on graceful restart
+const (
+	RestartReleaseState = "RELEASE=1"
+	RestartReadyState   = "READY=1"
+)
+
+/**
+Asks the parent process to drain requests and release the storage locks before the new process opens the storages on graceful restart,
+returns false if the process was started without it.
+*/
+func NotifyRestartRelease() (bool, error) {
+	return notifySocket(os.Getenv(RestartReadySocketEnv), RestartReleaseState)
+}
+
+/**
+Notifies the parent process that the new process is ready on graceful restart, returns false if the process was started without it.
+*/
+func NotifyRestartReady() (bool, error) {
+	socketPath := os.Getenv(RestartReadySocketEnv)
+	os.Unsetenv(RestartReadySocketEnv)
+	return notifySocket(socketPath, RestartReadyState)
+}
+
+func notifySocket(socketPath string, state string) (bool, error) {
+
+	socketAddr := &net.UnixAddr{
+		Name: socketPath,
+		Net:  "unixgram",
+	}
+
+	if socketAddr.Name == "" {
+		return false, nil
+	}
+
+	// abstract namespace socket
+	if socketAddr.Name[0] == '@' {
+		socketAddr.Name = "\x00" + socketAddr.Name[1:]
+	}
+
+	conn, err := net.DialUnix(socketAddr.Net, nil, socketAddr)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+/**
+Returns the watchdog interval configured by WatchdogSec in systemd service, zero if watchdog is disabled.
+*/
+func SdWatchdogInterval() time.Duration {
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" {
+		if p, err := strconv.Atoi(pid); err != nil || p != os.Getpid() {
+			return 0
+		}
+	}
+
+	return time.Duration(usec) * time.Microsecond
+}
@@ -16,6 +16,9 @@ package util
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 )
 
 func ParseClientAuth(s string) tls.ClientAuthType {
@@ -34,3 +37,33 @@ func ParseClientAuth(s string) tls.ClientAuthType {
 		return tls.NoClientCert
 	}
 }
+
+/**
+Private extension OID used to carry authorization roles in client certificates.
+*/
+var ClientRolesOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 59521, 1, 1}
+
+func ClientRolesExtension(roles []string) (pkix.Extension, error) {
+	value, err := asn1.Marshal(roles)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:    ClientRolesOID,
+		Value: value,
+	}, nil
+}
+
+func ParseClientRoles(cert *x509.Certificate) ([]string, bool) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(ClientRolesOID) {
+			continue
+		}
+		var roles []string
+		if _, err := asn1.Unmarshal(ext.Value, &roles); err != nil {
+			return nil, false
+		}
+		return roles, true
+	}
+	return nil, false
+}
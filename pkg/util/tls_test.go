@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestClientRoles(t *testing.T) {
+
+	ext, err := util.ClientRolesExtension([]string{"USER", "ADMIN"})
+	require.NoError(t, err)
+
+	cert := &x509.Certificate{
+		Extensions: []pkix.Extension{ext},
+	}
+
+	roles, ok := util.ParseClientRoles(cert)
+	require.True(t, ok)
+	require.Equal(t, []string{"USER", "ADMIN"}, roles)
+
+	_, ok = util.ParseClientRoles(&x509.Certificate{})
+	require.False(t, ok)
+
+}
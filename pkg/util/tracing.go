@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TracerName is the instrumentation name of the framework spans
+const TracerName = "github.com/codeallergy/sprintframework"
+
+// traceContextKey is the key of the field that carries the context to the trace core
+const traceContextKey = "traceContext"
+
+/**
+Returns the framework tracer, spans are not recorded until the tracing service installs the provider.
+*/
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+/**
+Starts the span as a child of the span in the context.
+*/
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+/**
+Records the error in the span and ends it.
+*/
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+/**
+Returns the field that carries the context, the trace core replaces it by trace and span ids of the span in the context.
+The field is skipped by the loggers without the trace core.
+*/
+func TraceContext(ctx context.Context) zap.Field {
+	return zap.Field{Key: traceContextKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+/**
+Wraps the core to attach trace and span ids to the entries of the loggers and log calls with TraceContext field.
+*/
+func TraceCore(core zapcore.Core) zapcore.Core {
+	return &traceCore{Core: core}
+}
+
+type traceCore struct {
+	zapcore.Core
+}
+
+func (t *traceCore) With(fields []zapcore.Field) zapcore.Core {
+	return &traceCore{Core: t.Core.With(expandTraceContext(fields))}
+}
+
+func (t *traceCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if t.Enabled(entry.Level) {
+		return checked.AddCore(entry, t)
+	}
+	return checked
+}
+
+func (t *traceCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return t.Core.Write(entry, expandTraceContext(fields))
+}
+
+func expandTraceContext(fields []zapcore.Field) []zapcore.Field {
+	for i, f := range fields {
+		if f.Key != traceContextKey || f.Type != zapcore.SkipType {
+			continue
+		}
+		expanded := make([]zapcore.Field, 0, len(fields) + 1)
+		expanded = append(expanded, fields[:i]...)
+		for _, f := range fields[i:] {
+			if ctx, ok := f.Interface.(context.Context); ok && f.Key == traceContextKey && f.Type == zapcore.SkipType {
+				expanded = append(expanded, traceFields(ctx)...)
+			} else {
+				expanded = append(expanded, f)
+			}
+		}
+		return expanded
+	}
+	return fields
+}
+
+func traceFields(ctx context.Context) []zapcore.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zapcore.Field{
+		zap.String("traceId", sc.TraceID().String()),
+		zap.String("spanId", sc.SpanID().String()),
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2022-2023 Zander Schwid & Co. LLC.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+package util_test
+
+import (
+	"context"
+	"github.com/codeallergy/sprintframework/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+func TestTraceCore(t *testing.T) {
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	core, logs := observer.New(zap.InfoLevel)
+	log := zap.New(util.TraceCore(core))
+
+	log.Info("Call", util.TraceContext(ctx), zap.String("zone", "example.com"))
+	log.With(util.TraceContext(ctx)).Info("With")
+	log.Info("Background", util.TraceContext(context.Background()))
+	log.Debug("Skipped", util.TraceContext(ctx))
+
+	entries := logs.All()
+	require.Equal(t, 3, len(entries))
+
+	require.Equal(t, map[string]interface{}{
+		"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"spanId":  "00f067aa0ba902b7",
+		"zone":    "example.com",
+	}, entries[0].ContextMap())
+
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entries[1].ContextMap()["traceId"])
+	require.Empty(t, entries[2].ContextMap())
+
+	// loggers without the trace core skip the field
+	plain, plainLogs := observer.New(zap.InfoLevel)
+	zap.New(plain).Info("Plain", util.TraceContext(ctx))
+	require.Empty(t, plainLogs.All()[0].ContextMap())
+}